@@ -16,11 +16,10 @@ import (
 	"io"
 	"net/http"
 	"os"
-	"path/filepath"
+	"runtime"
 	"strings"
 
 	"github.com/yorunikakeru4/oapi-codegen-dd/v3/pkg/codegen"
-	"go.yaml.in/yaml/v4"
 )
 
 const (
@@ -30,12 +29,30 @@ const (
 )
 
 var (
-	flagConfigFile string
-	flagPrintUsage bool
+	flagConfigFiles stringList
+	flagManifest    string
+	flagParallel    int
+	flagWatch       bool
+	flagPrintUsage  bool
 )
 
+// stringList is a flag that can be repeated.
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
 func main() {
-	flag.StringVar(&flagConfigFile, "config", "", "A YAML config file that controls oapi-codegen behavior.")
+	flag.Var(&flagConfigFiles, "config", "A YAML config file that controls oapi-codegen behavior. Repeat it to generate the spec with several configs.")
+	flag.StringVar(&flagManifest, "manifest", "", "A YAML file listing the spec and config of every target to generate.")
+	flag.IntVar(&flagParallel, "parallel", runtime.NumCPU(), "The number of targets generated in parallel.")
+	flag.BoolVar(&flagWatch, "watch", false, "Regenerate when the spec, overlays, user templates or config files change.")
 	flag.BoolVar(&flagPrintUsage, "help", false, "Show this help and exit.")
 
 	flag.Parse()
@@ -45,139 +62,90 @@ func main() {
 		os.Exit(0)
 	}
 
-	if flag.NArg() < 1 {
-		errExit("Please specify a path to a OpenAPI spec file")
-	} else if flag.NArg() > 1 {
-		errExit("Only one OpenAPI spec file is accepted and it must be the last CLI argument")
-	}
-
-	// Read the spec file (supports both local files and URLs)
-	specPath := flag.Arg(0)
-	specContents, err := readSpec(specPath)
-	if err != nil {
-		errExit("Error reading spec: %v", err)
-	}
-
-	// Read the config file
-	cfg := codegen.Configuration{}
-	hasConfigFile := flagConfigFile != ""
-	if hasConfigFile {
-		// #nosec G304 -- CLI tool intentionally reads user-specified config files
-		cfgContents, err := os.ReadFile(flagConfigFile)
-		if err != nil {
-			errExit("Error reading config file: %v", err)
+	var targets []target
+	if flagManifest != "" {
+		if flag.NArg() > 0 || len(flagConfigFiles) > 0 {
+			errExit("The specs and configs are listed in the manifest, they can't be passed as CLI arguments")
 		}
 
-		err = yaml.Unmarshal(cfgContents, &cfg)
+		var err error
+		targets, err = loadManifest(flagManifest)
 		if err != nil {
-			errExit("Error parsing config file: %v", err)
+			errExit("Error loading manifest: %v", err)
+		}
+	} else {
+		if flag.NArg() < 1 {
+			errExit("Please specify a path to a OpenAPI spec file")
+		} else if flag.NArg() > 1 {
+			errExit("Only one OpenAPI spec file is accepted and it must be the last CLI argument")
 		}
-	}
-
-	cfg = cfg.WithDefaults()
-
-	// If no config file was provided and input is a URL, output to stdout
-	// For local files without config, keep default behavior (write to gen.go)
-	if !hasConfigFile && (strings.HasPrefix(specPath, "http://") || strings.HasPrefix(specPath, "https://")) {
-		cfg.Output = nil
-	}
-
-	code, err := codegen.Generate(specContents, cfg)
-	if err != nil {
-		errExit("Error generating code: %v", err)
-	}
 
-	destDir := ""
-	destFile := ""
-	if cfg.Output != nil {
-		destDir = cfg.Output.Directory
-		if destDir != "" {
-			err = os.MkdirAll(destDir, generatedDirPerm)
-			if err != nil {
-				errExit("Error creating directory: %v", err)
-			}
+		specPath := flag.Arg(0)
+		if len(flagConfigFiles) == 0 {
+			targets = append(targets, target{Spec: specPath})
 		}
-		if cfg.Output.UseSingleFile {
-			destFile = filepath.Join(destDir, cfg.Output.Filename)
-		} else {
-			destDir = filepath.Join(destDir, cfg.PackageName)
-			err = os.MkdirAll(destDir, generatedDirPerm)
-			if err != nil {
-				errExit("Error creating directory: %v", err)
-			}
+		for _, cfgFile := range flagConfigFiles {
+			targets = append(targets, target{Spec: specPath, Config: cfgFile})
 		}
 	}
 
-	if destFile == "" && destDir == "" {
-		fmt.Print(code.GetCombined())
+	if flagWatch {
+		watch(flagManifest, targets, flagParallel)
 		return
 	}
 
-	if destFile != "" {
-		if err = os.WriteFile(destFile, []byte(code.GetCombined()), generatedFilePerm); err != nil {
-			errExit("Error writing file: %v", err)
-		}
+	if err := runTargets(targets, flagParallel); err != nil {
+		errExit("Error generating code: %v", err)
 	}
+}
 
-	for name, contents := range code {
-		isScaffold := codegen.IsScaffoldFile(name)
-		actualName := name
-		if isScaffold {
-			actualName = codegen.ScaffoldFileName(name)
-		}
-
-		// Skip "all" key (combined output for single-file mode)
-		if name == "all" {
-			continue
-		}
-
-		// In single-file mode, only write scaffold files
-		if destFile != "" && !isScaffold {
-			continue
-		}
+func errExit(msg string, args ...any) {
+	msg = msg + "\n"
+	_, _ = fmt.Fprintf(os.Stderr, msg, args...)
+	os.Exit(1)
+}
 
-		// Determine file path
-		var filePath string
-		if strings.Contains(actualName, "/") {
-			// Files with "/" have their full path already (e.g., "server/main")
-			filePath = actualName + ".go"
-		} else if destDir != "" {
-			filePath = filepath.Join(destDir, actualName+".go")
-		} else {
-			filePath = filepath.Join(filepath.Dir(destFile), actualName+".go")
+// generate generates code from an OpenAPI spec file path or URL, along with the filter report.
+// Relative external $refs of local files are resolved against the spec directory.
+func generate(path string, cfg codegen.Configuration) (codegen.GeneratedCode, codegen.FilterReport, error) {
+	if isURL(path) {
+		contents, err := fetchURL(path)
+		if err != nil {
+			return nil, codegen.FilterReport{}, fmt.Errorf("error reading spec: %w", err)
 		}
-
-		// Skip scaffold files if they exist and overwrite is not set
-		scaffoldOverwrite := cfg.Generate != nil && cfg.Generate.Handler != nil &&
-			cfg.Generate.Handler.Output != nil && cfg.Generate.Handler.Output.Overwrite
-		if isScaffold && !scaffoldOverwrite {
-			if _, err := os.Stat(filePath); err == nil {
-				continue
-			}
+		if contents, err = convertSwagger2(contents); err != nil {
+			return nil, codegen.FilterReport{}, err
 		}
+		return codegen.GenerateWithFilterReport(contents, cfg)
+	}
 
-		if err := os.MkdirAll(filepath.Dir(filePath), 0750); err != nil {
-			errExit("Error creating directory: %v", err)
-		}
-		if err = os.WriteFile(filePath, []byte(contents), generatedFilePerm); err != nil {
-			errExit("Error writing file: %v", err)
-		}
+	// #nosec G304 -- spec paths are user-specified
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, codegen.FilterReport{}, fmt.Errorf("error reading spec: %w", err)
 	}
+	// The file is converted again when loaded, external references of OpenAPI 3 specs need its path
+	if _, err = convertSwagger2(contents); err != nil {
+		return nil, codegen.FilterReport{}, err
+	}
+	return codegen.GenerateFromFileWithFilterReport(path, cfg)
 }
 
-func errExit(msg string, args ...any) {
-	msg = msg + "\n"
-	_, _ = fmt.Fprintf(os.Stderr, msg, args...)
-	os.Exit(1)
+// convertSwagger2 converts a Swagger 2.0 spec to OpenAPI 3, printing the constructs the conversion dropped.
+// Specs of other versions are returned unchanged.
+func convertSwagger2(contents []byte) ([]byte, error) {
+	contents, warnings, err := codegen.ConvertSwagger2(contents)
+	if err != nil {
+		return nil, err
+	}
+	for _, warning := range warnings {
+		logf("Warning: Swagger 2.0 conversion: %s", warning)
+	}
+	return contents, nil
 }
 
-// readSpec reads an OpenAPI spec from a file path or URL
-func readSpec(path string) ([]byte, error) {
-	if strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") {
-		return fetchURL(path)
-	}
-	// #nosec G304 -- CLI tool intentionally reads user-specified OpenAPI spec files
-	return os.ReadFile(path)
+func isURL(path string) bool {
+	return strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://")
 }
 
 // fetchURL fetches content from a URL
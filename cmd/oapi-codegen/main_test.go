@@ -0,0 +1,97 @@
+// Copyright 2025 DoorDash, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yorunikakeru4/oapi-codegen-dd/v3/pkg/codegen"
+)
+
+func TestGenerateSwagger2(t *testing.T) {
+	dir := t.TempDir()
+	cfg := codegen.Configuration{PackageName: "api"}
+
+	t.Run("conversion warnings are printed", func(t *testing.T) {
+		path := filepath.Join(dir, "api.yaml")
+		writeFile(t, path, `
+swagger: "2.0"
+info:
+  title: API
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      parameters:
+        - name: status
+          in: query
+          type: array
+          items:
+            type: string
+          collectionFormat: tsv
+      responses:
+        "204":
+          description: No content
+`)
+
+		stderr := captureStderr(t, func() {
+			code, _, err := generate(path, cfg)
+			require.NoError(t, err)
+			assert.NotEmpty(t, code)
+		})
+
+		assert.Contains(t, stderr, `Warning: Swagger 2.0 conversion: #/paths/~1pets/get/parameters: collectionFormat "tsv"`)
+	})
+
+	t.Run("external refs are rejected", func(t *testing.T) {
+		path := filepath.Join(dir, "refs.yaml")
+		writeFile(t, path, `
+swagger: "2.0"
+info:
+  title: API
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: OK
+          schema:
+            $ref: "./definitions.yaml#/Pet"
+`)
+
+		_, _, err := generate(path, cfg)
+		require.ErrorContains(t, err, "external references are not supported in Swagger 2.0 specs")
+		require.ErrorContains(t, err, "./definitions.yaml#/Pet")
+	})
+}
+
+// captureStderr returns what fn writes to os.Stderr.
+func captureStderr(t *testing.T, fn func()) string {
+	t.Helper()
+	f, err := os.Create(filepath.Join(t.TempDir(), "stderr"))
+	require.NoError(t, err)
+
+	stderr := os.Stderr
+	os.Stderr = f
+	defer func() { os.Stderr = stderr }()
+	fn()
+
+	contents, err := os.ReadFile(f.Name())
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	return string(contents)
+}
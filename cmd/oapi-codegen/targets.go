@@ -0,0 +1,290 @@
+// Copyright 2025 DoorDash, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/yorunikakeru4/oapi-codegen-dd/v3/pkg/codegen"
+	"go.yaml.in/yaml/v4"
+)
+
+// stdoutMu serializes the code printed by targets generated in parallel.
+var stdoutMu sync.Mutex
+
+// target is a spec generated with a configuration file.
+type target struct {
+	Spec string `yaml:"spec"`
+	// Config is the configuration file, the default configuration is used if empty.
+	Config string `yaml:"config,omitempty"`
+}
+
+// manifest lists the targets generated together.
+type manifest struct {
+	Targets []target `yaml:"targets"`
+}
+
+// loadManifest reads the targets of the manifest file.
+// Relative spec and configuration paths are resolved from the manifest directory.
+func loadManifest(path string) ([]target, error) {
+	// #nosec G304 -- CLI tool intentionally reads user-specified manifest files
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	var m manifest
+	if err = yaml.Unmarshal(contents, &m); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	if len(m.Targets) == 0 {
+		return nil, fmt.Errorf("%s has no targets", path)
+	}
+	dir := filepath.Dir(path)
+	for i, t := range m.Targets {
+		if t.Spec == "" {
+			return nil, fmt.Errorf("%s: target %d has no spec", path, i+1)
+		}
+		if !isURL(t.Spec) {
+			m.Targets[i].Spec = resolvePath(dir, t.Spec)
+		}
+		if t.Config != "" {
+			m.Targets[i].Config = resolvePath(dir, t.Config)
+		}
+	}
+	return m.Targets, nil
+}
+
+// resolvePath returns the path relative to dir, unless it's absolute.
+func resolvePath(dir, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(dir, path)
+}
+
+func (t target) String() string {
+	if t.Config == "" {
+		return t.Spec
+	}
+	return t.Spec + " (" + t.Config + ")"
+}
+
+// load reads the configuration of the target.
+func (t target) load() (codegen.Configuration, error) {
+	cfg := codegen.Configuration{}
+	if t.Config != "" {
+		// #nosec G304 -- CLI tool intentionally reads user-specified config files
+		cfgContents, err := os.ReadFile(t.Config)
+		if err != nil {
+			return cfg, fmt.Errorf("error reading config file: %w", err)
+		}
+
+		if err = yaml.Unmarshal(cfgContents, &cfg); err != nil {
+			return cfg, fmt.Errorf("error parsing config file: %w", err)
+		}
+	}
+
+	cfg = cfg.WithDefaults()
+
+	// If no config file was provided and input is a URL, output to stdout
+	// For local files without config, keep default behavior (write to gen.go)
+	if t.Config == "" && isURL(t.Spec) {
+		cfg.Output = nil
+	}
+	return cfg, nil
+}
+
+// watchedFiles returns the local files the code generated for the target depends on:
+// the spec and the files of its external $refs, the configuration file, the overlays and the user templates.
+func (t target) watchedFiles(cfg codegen.Configuration) []string {
+	var files []string
+	if !isURL(t.Spec) {
+		specFiles, err := codegen.SpecFiles(t.Spec, cfg)
+		if err != nil {
+			// The spec is still watched so that fixing it regenerates the code
+			specFiles = []string{t.Spec}
+		}
+		files = append(files, specFiles...)
+	}
+	if t.Config != "" {
+		files = append(files, t.Config)
+	}
+	if cfg.Overlay != nil {
+		for _, source := range cfg.Overlay.Sources {
+			if !isURL(source) {
+				files = append(files, source)
+			}
+		}
+	}
+	for _, tpl := range cfg.UserTemplates {
+		// Multi-line values are inline templates
+		if !strings.Contains(tpl, "\n") {
+			files = append(files, tpl)
+		}
+	}
+	return files
+}
+
+// run generates the code of the target and writes it, along with the filter report if configured.
+func (t target) run(cfg codegen.Configuration) error {
+	code, report, err := generate(t.Spec, cfg)
+	if err != nil {
+		return fmt.Errorf("error generating code: %w", err)
+	}
+	if cfg.Filter.Report != "" {
+		if err = writeFilterReport(cfg.Filter.Report, report); err != nil {
+			return err
+		}
+	}
+	return writeCode(code, cfg)
+}
+
+// writeFilterReport writes the report as YAML to path.
+func writeFilterReport(path string, report codegen.FilterReport) error {
+	contents, err := report.Marshal()
+	if err != nil {
+		return err
+	}
+	if err = os.WriteFile(path, contents, generatedFilePerm); err != nil {
+		return fmt.Errorf("error writing filter report: %w", err)
+	}
+	return nil
+}
+
+// runTargets generates the targets, up to parallel at a time.
+func runTargets(targets []target, parallel int) error {
+	var (
+		errs   []error
+		errsMu sync.Mutex
+		wg     sync.WaitGroup
+	)
+	addErr := func(t target, err error) {
+		errsMu.Lock()
+		defer errsMu.Unlock()
+		if len(targets) > 1 {
+			err = fmt.Errorf("%s: %w", t, err)
+		}
+		errs = append(errs, err)
+	}
+
+	sem := make(chan struct{}, max(parallel, 1))
+	for _, t := range targets {
+		cfg, err := t.load()
+		if err != nil {
+			addErr(t, err)
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			if err := t.run(cfg); err != nil {
+				addErr(t, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// writeCode writes the generated code to the output of the configuration, or prints it if there's none.
+func writeCode(code codegen.GeneratedCode, cfg codegen.Configuration) error {
+	destDir := ""
+	destFile := ""
+	if cfg.Output != nil {
+		destDir = cfg.Output.Directory
+		if destDir != "" {
+			if err := os.MkdirAll(destDir, generatedDirPerm); err != nil {
+				return fmt.Errorf("error creating directory: %w", err)
+			}
+		}
+		if cfg.Output.UseSingleFile {
+			destFile = filepath.Join(destDir, cfg.Output.Filename)
+		} else {
+			destDir = filepath.Join(destDir, cfg.PackageName)
+			if err := os.MkdirAll(destDir, generatedDirPerm); err != nil {
+				return fmt.Errorf("error creating directory: %w", err)
+			}
+		}
+	}
+
+	if destFile == "" && destDir == "" {
+		stdoutMu.Lock()
+		defer stdoutMu.Unlock()
+		fmt.Print(code.GetCombined())
+		return nil
+	}
+
+	if destFile != "" {
+		if err := os.WriteFile(destFile, []byte(code.GetCombined()), generatedFilePerm); err != nil {
+			return fmt.Errorf("error writing file: %w", err)
+		}
+	}
+
+	for name, contents := range code {
+		isScaffold := codegen.IsScaffoldFile(name)
+		actualName := name
+		if isScaffold {
+			actualName = codegen.ScaffoldFileName(name)
+		}
+
+		// Skip "all" key (combined output for single-file mode)
+		if name == "all" {
+			continue
+		}
+
+		// In single-file mode, only write scaffold files
+		if destFile != "" && !isScaffold {
+			continue
+		}
+
+		// Determine file path
+		var filePath string
+		if strings.Contains(actualName, "/") && !isScaffold && destDir != "" {
+			// Sub-package files are keyed by the sub-package (e.g., "pets/client")
+			filePath = filepath.Join(destDir, actualName+".go")
+		} else if strings.Contains(actualName, "/") {
+			// Files with "/" have their full path already (e.g., "server/main")
+			filePath = actualName + ".go"
+		} else if destDir != "" {
+			filePath = filepath.Join(destDir, actualName+".go")
+		} else {
+			filePath = filepath.Join(filepath.Dir(destFile), actualName+".go")
+		}
+
+		// Skip scaffold files if they exist and overwrite is not set
+		scaffoldOverwrite := cfg.Generate != nil && cfg.Generate.Handler != nil &&
+			cfg.Generate.Handler.Output != nil && cfg.Generate.Handler.Output.Overwrite
+		if isScaffold && !scaffoldOverwrite {
+			if _, err := os.Stat(filePath); err == nil {
+				continue
+			}
+		}
+
+		if err := os.MkdirAll(filepath.Dir(filePath), 0750); err != nil {
+			return fmt.Errorf("error creating directory: %w", err)
+		}
+		if err := os.WriteFile(filePath, []byte(contents), generatedFilePerm); err != nil {
+			return fmt.Errorf("error writing file: %w", err)
+		}
+	}
+	return nil
+}
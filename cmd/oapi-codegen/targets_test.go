@@ -0,0 +1,217 @@
+// Copyright 2025 DoorDash, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yorunikakeru4/oapi-codegen-dd/v3/pkg/codegen"
+	"go.yaml.in/yaml/v4"
+)
+
+func writeFile(t *testing.T, path, contents string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(contents), 0o600))
+}
+
+func TestLoadManifest(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("paths are relative to the manifest", func(t *testing.T) {
+		path := filepath.Join(dir, "api", "oapi-codegen.yaml")
+		writeFile(t, path, `
+targets:
+  - spec: specs/orders.yaml
+    config: configs/orders.yaml
+  - spec: /abs/users.yaml
+  - spec: https://example.com/spec.yaml
+`)
+
+		targets, err := loadManifest(path)
+		require.NoError(t, err)
+		assert.Equal(t, []target{
+			{Spec: filepath.Join(dir, "api", "specs", "orders.yaml"), Config: filepath.Join(dir, "api", "configs", "orders.yaml")},
+			{Spec: "/abs/users.yaml"},
+			{Spec: "https://example.com/spec.yaml"},
+		}, targets)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := loadManifest(filepath.Join(dir, "missing.yaml"))
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("no targets", func(t *testing.T) {
+		path := filepath.Join(dir, "empty.yaml")
+		writeFile(t, path, "targets: []\n")
+
+		_, err := loadManifest(path)
+		require.ErrorContains(t, err, "has no targets")
+	})
+
+	t.Run("target without spec", func(t *testing.T) {
+		path := filepath.Join(dir, "no-spec.yaml")
+		writeFile(t, path, "targets:\n  - config: config.yaml\n")
+
+		_, err := loadManifest(path)
+		require.ErrorContains(t, err, "target 1 has no spec")
+	})
+}
+
+func TestWatchedFiles(t *testing.T) {
+	t.Run("local files", func(t *testing.T) {
+		tgt := target{Spec: "api.yaml", Config: "config.yaml"}
+		cfg := codegen.Configuration{
+			Overlay: &codegen.OverlayOptions{
+				Sources: []string{"overlay.yaml", "https://example.com/overlay.yaml"},
+			},
+			UserTemplates: map[string]string{
+				"client.tmpl": "templates/client.tmpl",
+			},
+		}
+		assert.Equal(t, []string{"api.yaml", "config.yaml", "overlay.yaml", "templates/client.tmpl"}, tgt.watchedFiles(cfg))
+	})
+
+	t.Run("external refs", func(t *testing.T) {
+		dir := t.TempDir()
+		spec := filepath.Join(dir, "api.yaml")
+		writeFile(t, spec, `
+openapi: 3.0.0
+info:
+  title: API
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: "./schemas/pet.yaml#/Pet"
+`)
+		writeFile(t, filepath.Join(dir, "schemas", "pet.yaml"), "Pet:\n  type: object\n")
+
+		tgt := target{Spec: spec}
+		assert.ElementsMatch(t, []string{spec, filepath.Join(dir, "schemas", "pet.yaml")}, tgt.watchedFiles(codegen.Configuration{}))
+	})
+
+	t.Run("inline templates and remote spec", func(t *testing.T) {
+		tgt := target{Spec: "https://example.com/api.yaml"}
+		cfg := codegen.Configuration{
+			UserTemplates: map[string]string{
+				"client.tmpl": "{{ define \"client\" }}\n{{ end }}",
+			},
+		}
+		assert.Empty(t, tgt.watchedFiles(cfg))
+	})
+}
+
+func TestRunTargets(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "api.yaml"), `
+openapi: 3.0.0
+info:
+  title: API
+  version: 1.0.0
+paths:
+  /products:
+    get:
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: "#/components/schemas/ProductSku"
+components:
+  schemas:
+    ProductSku:
+      type: object
+      properties:
+        id:
+          type: string
+`)
+
+	var targets []target
+	for _, name := range []string{"default", "sku", "legacy"} {
+		config := filepath.Join(dir, name+".yaml")
+		contents := "package: api\noutput:\n  use-single-file: true\n  directory: " + filepath.Join(dir, name) + "\n"
+		switch name {
+		case "sku":
+			contents += "naming:\n  initialisms:\n    add: [SKU]\n"
+		case "legacy":
+			contents += "naming:\n  normalizer: legacy\n"
+		}
+		writeFile(t, config, contents)
+		targets = append(targets, target{Spec: filepath.Join(dir, "api.yaml"), Config: config})
+	}
+
+	require.NoError(t, runTargets(targets, len(targets)))
+
+	expected := map[string]string{
+		"default": "type ProductSku struct",
+		"sku":     "type ProductSKU struct",
+		"legacy":  "type ProductSku struct",
+	}
+	for name, decl := range expected {
+		code, err := os.ReadFile(filepath.Join(dir, name, "gen.go"))
+		require.NoError(t, err)
+		assert.Contains(t, string(code), decl)
+	}
+}
+
+func TestRunFilterReport(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	writeFile(t, filepath.Join(dir, "api.yaml"), `
+openapi: 3.0.0
+info:
+  title: API
+  version: 1.0.0
+paths:
+  /users:
+    get:
+      operationId: listUsers
+      responses:
+        "204":
+          description: No content
+  /admin:
+    get:
+      operationId: getAdmin
+      responses:
+        "204":
+          description: No content
+`)
+	config := filepath.Join(dir, "configs", "api.yaml")
+	writeFile(t, config, "package: api\noutput:\n  use-single-file: true\n  directory: api\n"+
+		"filter:\n  exclude:\n    paths: [/admin]\n  report: reports/filter.yaml\n")
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "reports"), 0o755))
+
+	require.NoError(t, runTargets([]target{{Spec: filepath.Join(dir, "api.yaml"), Config: config}}, 1))
+
+	// Like the output directory, the report is relative to the working directory
+	assert.FileExists(t, filepath.Join(dir, "api", "gen.go"))
+	contents, err := os.ReadFile(filepath.Join(dir, "reports", "filter.yaml"))
+	require.NoError(t, err)
+
+	var report codegen.FilterReport
+	require.NoError(t, yaml.Unmarshal(contents, &report))
+	assert.Equal(t, []codegen.FilteredOperation{
+		{Method: "GET", Path: "/admin", OperationID: "getAdmin", Reason: "exclude.paths"},
+	}, report.Operations)
+}
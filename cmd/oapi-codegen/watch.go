@@ -0,0 +1,125 @@
+// Copyright 2025 DoorDash, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"time"
+)
+
+// watchInterval is how often the watched files are checked for changes.
+const watchInterval = 500 * time.Millisecond
+
+// watcher regenerates the targets when the files they depend on change.
+type watcher struct {
+	manifestPath string
+	targets      []target
+	parallel     int
+
+	// files are the watched files of every target
+	files    map[target][]string
+	modTimes map[string]time.Time
+}
+
+// watch generates the targets, then regenerates them whenever their files change. It never returns.
+// The targets are reloaded from the manifest file when it changes.
+func watch(manifestPath string, targets []target, parallel int) {
+	w := &watcher{
+		manifestPath: manifestPath,
+		targets:      targets,
+		parallel:     parallel,
+		files:        make(map[target][]string),
+		modTimes:     make(map[string]time.Time),
+	}
+	if manifestPath != "" {
+		w.modTimes[manifestPath] = modTime(manifestPath)
+	}
+
+	w.run(targets)
+	logf("Watching for changes...")
+
+	for range time.Tick(watchInterval) {
+		changed := w.changedTargets()
+		for _, t := range changed {
+			logf("Regenerating %s", t)
+		}
+		if len(changed) > 0 {
+			w.run(changed)
+		}
+	}
+}
+
+// run generates the targets and records the files they depend on.
+func (w *watcher) run(targets []target) {
+	for _, t := range targets {
+		// The spec and configuration file are watched even if the configuration can't be loaded
+		cfg, _ := t.load()
+		w.files[t] = t.watchedFiles(cfg)
+		for _, file := range w.files[t] {
+			w.modTimes[file] = modTime(file)
+		}
+	}
+
+	start := time.Now()
+	if err := runTargets(targets, w.parallel); err != nil {
+		logf("Error generating code: %v", err)
+		return
+	}
+	logf("Generated %d target(s) in %s", len(targets), time.Since(start).Round(time.Millisecond))
+}
+
+// changedTargets returns the targets whose files changed since they were generated.
+func (w *watcher) changedTargets() []target {
+	changed := make(map[string]bool)
+	for file, recorded := range w.modTimes {
+		if t := modTime(file); !t.Equal(recorded) {
+			changed[file] = true
+			w.modTimes[file] = t
+		}
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+
+	if changed[w.manifestPath] {
+		targets, err := loadManifest(w.manifestPath)
+		if err != nil {
+			logf("Error reloading manifest: %v", err)
+			return nil
+		}
+		w.targets = targets
+		w.files = make(map[target][]string)
+		return targets
+	}
+
+	var res []target
+	for _, t := range w.targets {
+		if slices.ContainsFunc(w.files[t], func(file string) bool { return changed[file] }) {
+			res = append(res, t)
+		}
+	}
+	return res
+}
+
+// modTime returns the modification time of the file, zero if it doesn't exist.
+func modTime(file string) time.Time {
+	info, err := os.Stat(file)
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
+
+func logf(msg string, args ...any) {
+	_, _ = fmt.Fprintf(os.Stderr, msg+"\n", args...)
+}
@@ -0,0 +1,84 @@
+// Copyright 2025 DoorDash, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangedTargets(t *testing.T) {
+	dir := t.TempDir()
+	shared := filepath.Join(dir, "shared.yaml")
+	orders := filepath.Join(dir, "orders.yaml")
+	users := filepath.Join(dir, "users.yaml")
+	for _, file := range []string{shared, orders, users} {
+		writeFile(t, file, "openapi: 3.0.0\n")
+	}
+
+	ordersTarget := target{Spec: orders, Config: shared}
+	usersTarget := target{Spec: users, Config: shared}
+
+	newWatcher := func() *watcher {
+		w := &watcher{
+			targets: []target{ordersTarget, usersTarget},
+			files: map[target][]string{
+				ordersTarget: {orders, shared},
+				usersTarget:  {users, shared},
+			},
+			modTimes: make(map[string]time.Time),
+		}
+		for _, file := range []string{shared, orders, users} {
+			w.modTimes[file] = modTime(file)
+		}
+		return w
+	}
+	touch := func(t *testing.T, file string) {
+		t.Helper()
+		mtime := modTime(file).Add(time.Second)
+		require.NoError(t, os.Chtimes(file, mtime, mtime))
+	}
+
+	t.Run("nothing changed", func(t *testing.T) {
+		assert.Empty(t, newWatcher().changedTargets())
+	})
+
+	t.Run("spec changed", func(t *testing.T) {
+		w := newWatcher()
+		touch(t, users)
+		assert.Equal(t, []target{usersTarget}, w.changedTargets())
+		assert.Empty(t, w.changedTargets())
+	})
+
+	t.Run("shared file changed", func(t *testing.T) {
+		w := newWatcher()
+		touch(t, shared)
+		assert.Equal(t, []target{ordersTarget, usersTarget}, w.changedTargets())
+	})
+
+	t.Run("manifest changed", func(t *testing.T) {
+		manifestPath := filepath.Join(dir, "oapi-codegen.yaml")
+		writeFile(t, manifestPath, "targets:\n  - spec: orders.yaml\n")
+
+		w := newWatcher()
+		w.manifestPath = manifestPath
+		w.modTimes[manifestPath] = modTime(manifestPath)
+		touch(t, manifestPath)
+
+		assert.Equal(t, []target{{Spec: orders}}, w.changedTargets())
+		assert.Equal(t, []target{{Spec: orders}}, w.targets)
+	})
+}
@@ -0,0 +1,1132 @@
+// Code generated by oapi-codegen. DO NOT EDIT.
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/yorunikakeru4/oapi-codegen-dd/v3/pkg/runtime"
+)
+
+// Client is the client for the API implementing the Client interface.
+type Client struct {
+	apiClient runtime.APIClient
+}
+
+// NewClient creates a new instance of the Client client.
+func NewClient(apiClient runtime.APIClient) *Client {
+	return &Client{apiClient: apiClient}
+}
+
+// NewDefaultClient creates a new instance of the Client client with default api client.
+func NewDefaultClient(baseURL string, opts ...runtime.APIClientOption) (*Client, error) {
+	apiClient, err := runtime.NewAPIClient(baseURL, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating API client: %w", err)
+	}
+	return &Client{apiClient: apiClient}, nil
+}
+
+// ClientInterface is the interface for the API client.
+type ClientInterface interface {
+	GetPet(ctx context.Context, options *GetPetRequestOptions, reqEditors ...runtime.RequestEditorFn) (*GetPetResponse, error)
+	GetPetWithHeaders(ctx context.Context, options *GetPetRequestOptions, reqEditors ...runtime.RequestEditorFn) (*GetPetResponse, *GetPetResponseHeaders, error)
+
+	UploadPhoto(ctx context.Context, options *UploadPhotoRequestOptions, reqEditors ...runtime.RequestEditorFn) (*UploadPhotoResponse, error)
+
+	GetSession(ctx context.Context, options *GetSessionRequestOptions, reqEditors ...runtime.RequestEditorFn) (*GetSessionResponse, error)
+
+	StreamEvents(ctx context.Context, reqEditors ...runtime.RequestEditorFn) (*runtime.StreamResponse, error)
+	StreamEventsStream(ctx context.Context, reqEditors ...runtime.RequestEditorFn) (*runtime.EventStream[StreamEventsResponse], error)
+}
+
+func (c *Client) GetPet(ctx context.Context, options *GetPetRequestOptions, reqEditors ...runtime.RequestEditorFn) (*GetPetResponse, error) {
+	var err error
+	reqParams := runtime.RequestOptionsParameters{
+		OperationID: "GetPet",
+		RequestURL:  c.apiClient.GetBaseURL() + "/pets/{id}",
+		Method:      "GET",
+		Options:     options,
+	}
+
+	req, err := c.apiClient.CreateRequest(ctx, reqParams, reqEditors...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+
+	responseParser := func(ctx context.Context, resp *runtime.Response) (*GetPetResponse, error) {
+		bodyBytes := resp.Content
+		if resp.StatusCode != 200 {
+			target := new(GetPetErrorResponse)
+			err = json.Unmarshal(bodyBytes, target)
+			if err != nil {
+				return nil, fmt.Errorf("error decoding response: %w", err)
+			}
+
+			if errTarget, ok := any(*target).(error); ok {
+				return nil, runtime.NewClientAPIError(errTarget, runtime.WithStatusCode(resp.StatusCode))
+			}
+			return nil, runtime.NewClientAPIError(fmt.Errorf("API error (status %d): %v", resp.StatusCode, *target),
+				runtime.WithStatusCode(resp.StatusCode))
+		}
+		target := new(GetPetResponse)
+		if err = json.Unmarshal(bodyBytes, target); err != nil {
+			err = fmt.Errorf("error decoding response: %w", err)
+			return nil, err
+		}
+		return target, nil
+	}
+
+	resp, err := c.apiClient.ExecuteRequest(ctx, req, "/pets/{id}")
+	if err != nil {
+		return nil, fmt.Errorf("error executing request: %w", err)
+	}
+	return responseParser(ctx, resp)
+}
+
+// GetPetWithHeaders calls GetPet and also returns the headers declared by its success response.
+func (c *Client) GetPetWithHeaders(ctx context.Context, options *GetPetRequestOptions, reqEditors ...runtime.RequestEditorFn) (*GetPetResponse, *GetPetResponseHeaders, error) {
+	var err error
+	reqParams := runtime.RequestOptionsParameters{
+		OperationID: "GetPet",
+		RequestURL:  c.apiClient.GetBaseURL() + "/pets/{id}",
+		Method:      "GET",
+		Options:     options,
+	}
+
+	req, err := c.apiClient.CreateRequest(ctx, reqParams, reqEditors...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error creating request: %w", err)
+	}
+
+	responseParser := func(ctx context.Context, resp *runtime.Response) (*GetPetResponse, error) {
+		bodyBytes := resp.Content
+		if resp.StatusCode != 200 {
+			target := new(GetPetErrorResponse)
+			err = json.Unmarshal(bodyBytes, target)
+			if err != nil {
+				return nil, fmt.Errorf("error decoding response: %w", err)
+			}
+
+			if errTarget, ok := any(*target).(error); ok {
+				return nil, runtime.NewClientAPIError(errTarget, runtime.WithStatusCode(resp.StatusCode))
+			}
+			return nil, runtime.NewClientAPIError(fmt.Errorf("API error (status %d): %v", resp.StatusCode, *target),
+				runtime.WithStatusCode(resp.StatusCode))
+		}
+		target := new(GetPetResponse)
+		if err = json.Unmarshal(bodyBytes, target); err != nil {
+			err = fmt.Errorf("error decoding response: %w", err)
+			return nil, err
+		}
+		return target, nil
+	}
+
+	resp, err := c.apiClient.ExecuteRequest(ctx, req, "/pets/{id}")
+	if err != nil {
+		return nil, nil, fmt.Errorf("error executing request: %w", err)
+	}
+	res, err := responseParser(ctx, resp)
+	if err != nil {
+		return nil, nil, err
+	}
+	headers, err := ParseGetPetResponseHeaders(resp.Headers)
+	if err != nil {
+		return nil, nil, err
+	}
+	return res, headers, nil
+}
+
+// ParseGetPetResponseHeaders decodes the headers declared by the success response of GetPet.
+// Missing headers are left unset.
+func ParseGetPetResponseHeaders(header http.Header) (*GetPetResponseHeaders, error) {
+	res := &GetPetResponseHeaders{}
+	if value := header.Get("X-Rate-Limit-Remaining"); value != "" {
+		v, err := runtime.ParseString[int](value)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding response header %s: %w", "X-Rate-Limit-Remaining", err)
+		}
+		res.XRateLimitRemaining = v
+	}
+	return res, nil
+}
+
+func (c *Client) UploadPhoto(ctx context.Context, options *UploadPhotoRequestOptions, reqEditors ...runtime.RequestEditorFn) (*UploadPhotoResponse, error) {
+	var err error
+	reqParams := runtime.RequestOptionsParameters{
+		OperationID: "UploadPhoto",
+		RequestURL:  c.apiClient.GetBaseURL() + "/pets/{id}/photo",
+		Method:      "POST",
+		Options:     options,
+		ContentType: "multipart/form-data",
+	}
+
+	req, err := c.apiClient.CreateRequest(ctx, reqParams, reqEditors...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+
+	responseParser := func(ctx context.Context, resp *runtime.Response) (*UploadPhotoResponse, error) {
+		bodyBytes := resp.Content
+		if resp.StatusCode != 201 {
+			return nil, runtime.NewClientAPIError(fmt.Errorf("unexpected status code: %d", resp.StatusCode),
+				runtime.WithStatusCode(resp.StatusCode))
+		}
+		target := new(UploadPhotoResponse)
+		if err = json.Unmarshal(bodyBytes, target); err != nil {
+			err = fmt.Errorf("error decoding response: %w", err)
+			return nil, err
+		}
+		return target, nil
+	}
+
+	resp, err := c.apiClient.ExecuteRequest(ctx, req, "/pets/{id}/photo")
+	if err != nil {
+		return nil, fmt.Errorf("error executing request: %w", err)
+	}
+	return responseParser(ctx, resp)
+}
+
+func (c *Client) GetSession(ctx context.Context, options *GetSessionRequestOptions, reqEditors ...runtime.RequestEditorFn) (*GetSessionResponse, error) {
+	var err error
+	reqParams := runtime.RequestOptionsParameters{
+		OperationID: "GetSession",
+		RequestURL:  c.apiClient.GetBaseURL() + "/session",
+		Method:      "GET",
+		Options:     options,
+	}
+
+	req, err := c.apiClient.CreateRequest(ctx, reqParams, reqEditors...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+
+	responseParser := func(ctx context.Context, resp *runtime.Response) (*GetSessionResponse, error) {
+		bodyBytes := resp.Content
+		if resp.StatusCode != 200 {
+			return nil, runtime.NewClientAPIError(fmt.Errorf("unexpected status code: %d", resp.StatusCode),
+				runtime.WithStatusCode(resp.StatusCode))
+		}
+		target := new(GetSessionResponse)
+		if err = json.Unmarshal(bodyBytes, target); err != nil {
+			err = fmt.Errorf("error decoding response: %w", err)
+			return nil, err
+		}
+		return target, nil
+	}
+
+	resp, err := c.apiClient.ExecuteRequest(ctx, req, "/session")
+	if err != nil {
+		return nil, fmt.Errorf("error executing request: %w", err)
+	}
+	return responseParser(ctx, resp)
+}
+
+func (c *Client) StreamEvents(ctx context.Context, reqEditors ...runtime.RequestEditorFn) (*runtime.StreamResponse, error) {
+	var err error
+	reqParams := runtime.RequestOptionsParameters{
+		OperationID: "StreamEvents",
+		RequestURL:  c.apiClient.GetBaseURL() + "/events",
+		Method:      "GET",
+	}
+
+	req, err := c.apiClient.CreateRequest(ctx, reqParams, reqEditors...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+
+	responseParser := func(ctx context.Context, resp *runtime.StreamResponse) (*runtime.StreamResponse, error) {
+		if resp.StatusCode != 200 {
+			defer func() { _ = resp.Body.Close() }()
+			return nil, runtime.NewClientAPIError(fmt.Errorf("unexpected status code: %d", resp.StatusCode),
+				runtime.WithStatusCode(resp.StatusCode))
+		}
+		return resp, nil
+	}
+
+	streamClient, ok := c.apiClient.(runtime.StreamingAPIClient)
+	if !ok {
+		return nil, fmt.Errorf("error executing request: %w", runtime.ErrStreamingNotSupported)
+	}
+	resp, err := streamClient.ExecuteRequestStream(ctx, req, "/events")
+	if err != nil {
+		return nil, fmt.Errorf("error executing request: %w", err)
+	}
+	return responseParser(ctx, resp)
+}
+
+// StreamEventsStream calls StreamEvents and reads the Server-Sent Events of the response,
+// decoding their data into StreamEventsResponse.
+// The stream must be closed once done with, unless all events are consumed with Events.
+func (c *Client) StreamEventsStream(ctx context.Context, reqEditors ...runtime.RequestEditorFn) (*runtime.EventStream[StreamEventsResponse], error) {
+	resp, err := c.StreamEvents(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return runtime.NewEventStream[StreamEventsResponse](resp), nil
+}
+
+var _ ClientInterface = (*Client)(nil)
+
+// GetPetRequestOptions is the options needed to make a request to GetPet.
+type GetPetRequestOptions struct {
+	PathParams *GetPetPath
+}
+
+// Validate validates all the fields in the options.
+// Use it if fields validation was not run.
+func (o *GetPetRequestOptions) Validate() error {
+	var errors runtime.ValidationErrors
+
+	if o.PathParams != nil {
+		if v, ok := any(o.PathParams).(runtime.Validator); ok {
+			if err := v.Validate(); err != nil {
+				errors = errors.Append("PathParams", err)
+			}
+		}
+	}
+	if len(errors) == 0 {
+		return nil
+	}
+
+	return errors
+}
+
+// GetPathParams returns the path params as a map.
+func (o *GetPetRequestOptions) GetPathParams() (map[string]any, error) {
+	return runtime.AsMap[any](o.PathParams)
+}
+
+// GetQuery returns the query params as a map.
+func (o *GetPetRequestOptions) GetQuery() (map[string]any, error) {
+	return nil, nil
+}
+
+// GetBody returns the payload in any type that can be marshalled to JSON by the client.
+func (o *GetPetRequestOptions) GetBody() any {
+	return nil
+}
+
+// GetHeader returns the headers as a map.
+func (o *GetPetRequestOptions) GetHeader() (map[string]string, error) {
+	return nil, nil
+}
+
+// GetCookies returns the cookie params as a map.
+func (o *GetPetRequestOptions) GetCookies() (map[string]any, error) {
+	return nil, nil
+}
+
+// UploadPhotoRequestOptions is the options needed to make a request to UploadPhoto.
+type UploadPhotoRequestOptions struct {
+	PathParams *UploadPhotoPath
+	Body       *UploadPhotoBody
+}
+
+// Validate validates all the fields in the options.
+// Use it if fields validation was not run.
+func (o *UploadPhotoRequestOptions) Validate() error {
+	var errors runtime.ValidationErrors
+
+	if o.PathParams != nil {
+		if v, ok := any(o.PathParams).(runtime.Validator); ok {
+			if err := v.Validate(); err != nil {
+				errors = errors.Append("PathParams", err)
+			}
+		}
+	}
+
+	if o.Body != nil {
+		if v, ok := any(o.Body).(runtime.Validator); ok {
+			if err := v.Validate(); err != nil {
+				errors = errors.Append("Body", err)
+			}
+		}
+	}
+	if len(errors) == 0 {
+		return nil
+	}
+
+	return errors
+}
+
+// GetPathParams returns the path params as a map.
+func (o *UploadPhotoRequestOptions) GetPathParams() (map[string]any, error) {
+	return runtime.AsMap[any](o.PathParams)
+}
+
+// GetQuery returns the query params as a map.
+func (o *UploadPhotoRequestOptions) GetQuery() (map[string]any, error) {
+	return nil, nil
+}
+
+// GetBody returns the payload in any type that can be marshalled to JSON by the client.
+func (o *UploadPhotoRequestOptions) GetBody() any {
+	return o.Body
+}
+
+// GetHeader returns the headers as a map.
+func (o *UploadPhotoRequestOptions) GetHeader() (map[string]string, error) {
+	return nil, nil
+}
+
+// GetCookies returns the cookie params as a map.
+func (o *UploadPhotoRequestOptions) GetCookies() (map[string]any, error) {
+	return nil, nil
+}
+
+// GetSessionRequestOptions is the options needed to make a request to GetSession.
+type GetSessionRequestOptions struct {
+	Cookies *GetSessionCookies
+}
+
+// Validate validates all the fields in the options.
+// Use it if fields validation was not run.
+func (o *GetSessionRequestOptions) Validate() error {
+	var errors runtime.ValidationErrors
+
+	if o.Cookies != nil {
+		if v, ok := any(o.Cookies).(runtime.Validator); ok {
+			if err := v.Validate(); err != nil {
+				errors = errors.Append("Cookies", err)
+			}
+		}
+	}
+	if len(errors) == 0 {
+		return nil
+	}
+
+	return errors
+}
+
+// GetPathParams returns the path params as a map.
+func (o *GetSessionRequestOptions) GetPathParams() (map[string]any, error) {
+	return nil, nil
+}
+
+// GetQuery returns the query params as a map.
+func (o *GetSessionRequestOptions) GetQuery() (map[string]any, error) {
+	return nil, nil
+}
+
+// GetBody returns the payload in any type that can be marshalled to JSON by the client.
+func (o *GetSessionRequestOptions) GetBody() any {
+	return nil
+}
+
+// GetHeader returns the headers as a map.
+func (o *GetSessionRequestOptions) GetHeader() (map[string]string, error) {
+	return nil, nil
+}
+
+// GetCookies returns the cookie params as a map.
+func (o *GetSessionRequestOptions) GetCookies() (map[string]any, error) {
+	return runtime.AsMap[any](o.Cookies)
+}
+
+type GetSessionCookies struct {
+	SessionID string  `json:"session_id" validate:"required"`
+	Theme     *string `json:"theme,omitempty"`
+}
+
+// OapiErrorKind represents the type of error that occurred during request processing.
+type OapiErrorKind int
+
+const (
+	// OapiErrorKindParse indicates a parameter parsing error (invalid path/query/header parameter).
+	OapiErrorKindParse OapiErrorKind = iota
+
+	// OapiErrorKindDecode indicates a request body decoding error (invalid JSON, form data, etc.).
+	OapiErrorKindDecode
+
+	// OapiErrorKindValidation indicates a request validation error (failed schema validation).
+	OapiErrorKindValidation
+
+	// OapiErrorKindService indicates a service/business logic error returned by the service implementation.
+	OapiErrorKindService
+
+	// OapiErrorKindSecurity indicates that the request failed the security requirements of the operation.
+	OapiErrorKindSecurity
+)
+
+// OapiHandlerError represents an error that occurred during request handling (parse, decode, validation).
+// When no typed error response is configured in the OpenAPI spec, this error type is used.
+// Custom error handlers can type-assert to this type to access error details.
+type OapiHandlerError struct {
+	Kind          OapiErrorKind
+	OperationID   string
+	Message       string
+	ParamName     string
+	ParamLocation string
+}
+
+func (e OapiHandlerError) Error() string {
+	return e.Message
+}
+
+// OapiErrorResponse is the default JSON error response structure used by OapiDefaultErrorHandler.
+type OapiErrorResponse struct {
+	Error         string `json:"error"`
+	OperationID   string `json:"operation_id,omitempty"`
+	ParamName     string `json:"param_name,omitempty"`
+	ParamLocation string `json:"param_location,omitempty"`
+}
+
+// OapiErrorHandler handles errors that occur during request processing.
+// Implement this interface to customize error responses, logging, and metrics.
+type OapiErrorHandler interface {
+	// HandleError writes an error response to w with the given status code.
+	// The err is either an OapiHandlerError (for parse/decode/validation errors)
+	// or a typed error matching the OpenAPI spec's error response schema.
+	HandleError(w http.ResponseWriter, r *http.Request, statusCode int, err error)
+}
+
+// OapiDefaultErrorHandler provides the default error handling behavior.
+// It writes JSON error responses. For OapiHandlerError, it uses OapiErrorResponse.
+// For typed errors (from OpenAPI spec), it encodes them directly.
+type OapiDefaultErrorHandler struct{}
+
+// HandleError implements OapiErrorHandler with default JSON error responses.
+func (h *OapiDefaultErrorHandler) HandleError(w http.ResponseWriter, r *http.Request, statusCode int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+
+	if handlerErr, ok := err.(OapiHandlerError); ok {
+		_ = json.NewEncoder(w).Encode(OapiErrorResponse{
+			Error:         handlerErr.Message,
+			OperationID:   handlerErr.OperationID,
+			ParamName:     handlerErr.ParamName,
+			ParamLocation: handlerErr.ParamLocation,
+		})
+		return
+	}
+
+	// Typed error from OpenAPI spec - encode directly
+	_ = json.NewEncoder(w).Encode(err)
+}
+
+// ServiceInterface defines the service interface for business logic.
+type ServiceInterface interface {
+	GetPet(ctx context.Context, opts *GetPetServiceRequestOptions) (GetPetResponseObject, error)
+
+	UploadPhoto(ctx context.Context, opts *UploadPhotoServiceRequestOptions) (UploadPhotoResponseObject, error)
+
+	GetSession(ctx context.Context, opts *GetSessionServiceRequestOptions) (GetSessionResponseObject, error)
+
+	StreamEvents(ctx context.Context, events *runtime.EventSender[StreamEventsResponse]) error
+}
+
+// HTTPAdapter adapts the ServiceInterface to HTTP handlers.
+// This struct is generated and should not be modified.
+type HTTPAdapter struct {
+	svc        ServiceInterface
+	errHandler OapiErrorHandler
+}
+
+// HTTPAdapterOption configures an HTTPAdapter.
+type HTTPAdapterOption func(*HTTPAdapter)
+
+// NewHTTPAdapter creates a new HTTPAdapter wrapping the given service.
+// If errHandler is nil, OapiDefaultErrorHandler is used.
+func NewHTTPAdapter(svc ServiceInterface, errHandler OapiErrorHandler, opts ...HTTPAdapterOption) *HTTPAdapter {
+	if errHandler == nil {
+		errHandler = &OapiDefaultErrorHandler{}
+	}
+	a := &HTTPAdapter{svc: svc, errHandler: errHandler}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
+}
+
+// GetPet handles GET /pets/{id}
+func (a *HTTPAdapter) GetPet(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	opts := &GetPetServiceRequestOptions{}
+	opts.RawRequest = r
+
+	// Parse path parameters
+	pathParams := &GetPetPath{}
+	pathParamIDStr := r.PathValue("id")
+
+	pathParamID, err := runtime.ParseString[int](pathParamIDStr)
+	if err != nil {
+		a.errHandler.HandleError(w, r, http.StatusBadRequest, OapiHandlerError{
+			Kind:          OapiErrorKindParse,
+			OperationID:   "GetPet",
+			Message:       err.Error(),
+			ParamName:     "id",
+			ParamLocation: "path",
+		})
+		return
+	}
+	pathParams.ID = pathParamID
+	opts.PathParams = pathParams
+
+	// Call business logic
+	resp, err := a.svc.GetPet(ctx, opts)
+	if err != nil {
+		code := http.StatusInternalServerError
+		a.errHandler.HandleError(w, r, code, err)
+		return
+	}
+
+	if resp == nil {
+		a.errHandler.HandleError(w, r, http.StatusInternalServerError, OapiHandlerError{
+			Kind:        OapiErrorKindService,
+			OperationID: "GetPet",
+			Message:     "no response returned",
+		})
+		return
+	}
+
+	if err := resp.writeGetPetResponse(w); err != nil {
+		a.errHandler.HandleError(w, r, http.StatusInternalServerError, OapiHandlerError{
+			Kind:        OapiErrorKindService,
+			OperationID: "GetPet",
+			Message:     fmt.Sprintf("encoding response failed: %v", err),
+		})
+	}
+}
+
+// UploadPhoto handles POST /pets/{id}/photo
+func (a *HTTPAdapter) UploadPhoto(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	opts := &UploadPhotoServiceRequestOptions{}
+	opts.RawRequest = r
+
+	// Parse path parameters
+	pathParams := &UploadPhotoPath{}
+	pathParamIDStr := r.PathValue("id")
+
+	pathParamID, err := runtime.ParseString[int](pathParamIDStr)
+	if err != nil {
+		a.errHandler.HandleError(w, r, http.StatusBadRequest, OapiHandlerError{
+			Kind:          OapiErrorKindParse,
+			OperationID:   "UploadPhoto",
+			Message:       err.Error(),
+			ParamName:     "id",
+			ParamLocation: "path",
+		})
+		return
+	}
+	pathParams.ID = pathParamID
+	opts.PathParams = pathParams
+	// Parse request body
+	defer r.Body.Close()
+	if err := r.ParseMultipartForm(32 << 20); err != nil {
+		a.errHandler.HandleError(w, r, http.StatusBadRequest, OapiHandlerError{
+			Kind:        OapiErrorKindDecode,
+			OperationID: "UploadPhoto",
+			Message:     err.Error(),
+		})
+		return
+	}
+	var body UploadPhotoBody
+	if values := r.MultipartForm.Value["caption"]; len(values) > 0 {
+
+		body.Caption = &values[0]
+	}
+	if fileHeaders := r.MultipartForm.File["file"]; len(fileHeaders) > 0 {
+		body.File.InitFromMultipart(fileHeaders[0])
+	}
+	opts.Body = &body
+
+	// Call business logic
+	resp, err := a.svc.UploadPhoto(ctx, opts)
+	if err != nil {
+		code := http.StatusInternalServerError
+		a.errHandler.HandleError(w, r, code, err)
+		return
+	}
+
+	if resp == nil {
+		a.errHandler.HandleError(w, r, http.StatusInternalServerError, OapiHandlerError{
+			Kind:        OapiErrorKindService,
+			OperationID: "UploadPhoto",
+			Message:     "no response returned",
+		})
+		return
+	}
+
+	if err := resp.writeUploadPhotoResponse(w); err != nil {
+		a.errHandler.HandleError(w, r, http.StatusInternalServerError, OapiHandlerError{
+			Kind:        OapiErrorKindService,
+			OperationID: "UploadPhoto",
+			Message:     fmt.Sprintf("encoding response failed: %v", err),
+		})
+	}
+}
+
+// GetSession handles GET /session
+func (a *HTTPAdapter) GetSession(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	opts := &GetSessionServiceRequestOptions{}
+	opts.RawRequest = r
+
+	// Parse cookie parameters
+	cookieParams := &GetSessionCookies{}
+	if cookie, err := r.Cookie("session_id"); err == nil {
+		cookieParamSessionID := cookie.Value
+		cookieParams.SessionID = cookieParamSessionID
+	}
+	if cookie, err := r.Cookie("theme"); err == nil {
+		cookieParamTheme := cookie.Value
+		cookieParams.Theme = &cookieParamTheme
+	}
+	opts.Cookies = cookieParams
+
+	// Call business logic
+	resp, err := a.svc.GetSession(ctx, opts)
+	if err != nil {
+		code := http.StatusInternalServerError
+		a.errHandler.HandleError(w, r, code, err)
+		return
+	}
+
+	if resp == nil {
+		a.errHandler.HandleError(w, r, http.StatusInternalServerError, OapiHandlerError{
+			Kind:        OapiErrorKindService,
+			OperationID: "GetSession",
+			Message:     "no response returned",
+		})
+		return
+	}
+
+	if err := resp.writeGetSessionResponse(w); err != nil {
+		a.errHandler.HandleError(w, r, http.StatusInternalServerError, OapiHandlerError{
+			Kind:        OapiErrorKindService,
+			OperationID: "GetSession",
+			Message:     fmt.Sprintf("encoding response failed: %v", err),
+		})
+	}
+}
+
+// StreamEvents handles GET /events
+func (a *HTTPAdapter) StreamEvents(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Call business logic, streaming events
+	events := runtime.NewEventSender[StreamEventsResponse](w, 200)
+	err := a.svc.StreamEvents(ctx, events)
+	if events.Started() {
+		// Errors can't be reported once the response is sent
+		return
+	}
+	if err != nil {
+		code := http.StatusInternalServerError
+		a.errHandler.HandleError(w, r, code, err)
+		return
+	}
+
+	_ = events.Start()
+}
+
+// RouterOption is a function that configures the router.
+type RouterOption func(*routerConfig)
+
+type routerConfig struct {
+	middlewares []func(http.Handler) http.Handler
+	errHandler  OapiErrorHandler
+	adapterOpts []HTTPAdapterOption
+}
+
+// WithMiddleware adds middleware to the router.
+func WithMiddleware(mw func(http.Handler) http.Handler) RouterOption {
+	return func(cfg *routerConfig) {
+		cfg.middlewares = append(cfg.middlewares, mw)
+	}
+}
+
+// WithErrorHandler sets a custom error handler for the router.
+// If not set, OapiDefaultErrorHandler is used.
+func WithErrorHandler(h OapiErrorHandler) RouterOption {
+	return func(cfg *routerConfig) {
+		cfg.errHandler = h
+	}
+}
+
+// WithAdapterOptions passes options to the HTTPAdapter created by the router.
+func WithAdapterOptions(opts ...HTTPAdapterOption) RouterOption {
+	return func(cfg *routerConfig) {
+		cfg.adapterOpts = append(cfg.adapterOpts, opts...)
+	}
+}
+
+// NewRouter creates a new http.ServeMux with the given service implementation.
+func NewRouter(svc ServiceInterface, opts ...RouterOption) *http.ServeMux {
+	cfg := &routerConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	adapter := NewHTTPAdapter(svc, cfg.errHandler, cfg.adapterOpts...)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /pets/{id}", applyMiddleware(http.HandlerFunc(adapter.GetPet), cfg.middlewares...))
+	mux.HandleFunc("POST /pets/{id}/photo", applyMiddleware(http.HandlerFunc(adapter.UploadPhoto), cfg.middlewares...))
+	mux.HandleFunc("GET /session", applyMiddleware(http.HandlerFunc(adapter.GetSession), cfg.middlewares...))
+	mux.HandleFunc("GET /events", applyMiddleware(http.HandlerFunc(adapter.StreamEvents), cfg.middlewares...))
+
+	return mux
+}
+
+// applyMiddleware wraps a handler with the given middleware chain.
+func applyMiddleware(h http.Handler, middlewares ...func(http.Handler) http.Handler) http.HandlerFunc {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		h = middlewares[i](h)
+	}
+	return h.ServeHTTP
+}
+
+type GetPetResponseHeaders struct {
+	XRateLimitRemaining int `json:"X-Rate-Limit-Remaining"`
+}
+
+type GetPetPath struct {
+	ID int `json:"id" validate:"required"`
+}
+
+func (g GetPetPath) Validate() error {
+	return runtime.ConvertValidatorError(typesValidator.Struct(g))
+}
+
+type UploadPhotoPath struct {
+	ID int `json:"id" validate:"required"`
+}
+
+func (u UploadPhotoPath) Validate() error {
+	return runtime.ConvertValidatorError(typesValidator.Struct(u))
+}
+
+type UploadPhotoBody struct {
+	Caption *string      `json:"caption,omitempty"`
+	File    runtime.File `json:"file" validate:"required"`
+}
+
+func (u UploadPhotoBody) Validate() error {
+	var errors runtime.ValidationErrors
+	if v, ok := any(u.File).(runtime.Validator); ok {
+		if err := v.Validate(); err != nil {
+			errors = errors.Append("File", err)
+		}
+	}
+	if len(errors) == 0 {
+		return nil
+	}
+	return errors
+}
+
+// GetPetResponseObject is a response of GetPet, implemented by GetPet200JSON, GetPet404JSON.
+type GetPetResponseObject interface {
+	// writeGetPetResponse writes the response, failing before anything is written if the body can't be encoded.
+	writeGetPetResponse(w http.ResponseWriter) error
+}
+
+// GetPet200JSON is the 200 response of GetPet.
+type GetPet200JSON struct {
+	Body    GetPetResponse
+	Headers http.Header
+	// ResponseHeaders holds the headers declared by the spec, set over Headers.
+	ResponseHeaders GetPetResponseHeaders
+}
+
+func (r GetPet200JSON) writeGetPetResponse(w http.ResponseWriter) error {
+	data, err := json.Marshal(r.Body)
+	if err != nil {
+		return err
+	}
+	for k, v := range r.Headers {
+		for _, val := range v {
+			w.Header().Add(k, val)
+		}
+	}
+	for k, v := range r.ResponseHeaders.HTTPHeader() {
+		w.Header()[k] = v
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	_, _ = w.Write(data)
+	return nil
+}
+
+// Validate validates the body and the headers declared by the spec.
+func (r GetPet200JSON) Validate() error {
+	if v, ok := any(r.Body).(runtime.Validator); ok {
+		if err := v.Validate(); err != nil {
+			return err
+		}
+	}
+	if v, ok := any(r.ResponseHeaders).(runtime.Validator); ok {
+		return v.Validate()
+	}
+	return nil
+}
+
+// GetPet404JSON is the 404 response of GetPet.
+type GetPet404JSON struct {
+	Body    GetPetErrorResponse
+	Headers http.Header
+}
+
+func (r GetPet404JSON) writeGetPetResponse(w http.ResponseWriter) error {
+	data, err := json.Marshal(r.Body)
+	if err != nil {
+		return err
+	}
+	for k, v := range r.Headers {
+		for _, val := range v {
+			w.Header().Add(k, val)
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+	_, _ = w.Write(data)
+	return nil
+}
+
+// Validate validates the body and the headers declared by the spec.
+func (r GetPet404JSON) Validate() error {
+	if v, ok := any(r.Body).(runtime.Validator); ok {
+		if err := v.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// HTTPHeader returns the headers set in h, leaving out unset optional headers and empty values.
+func (h GetPetResponseHeaders) HTTPHeader() http.Header {
+	header := http.Header{}
+	header.Set("X-Rate-Limit-Remaining", runtime.FormatString(h.XRateLimitRemaining))
+	return header
+}
+
+// UploadPhotoResponseObject is a response of UploadPhoto, implemented by UploadPhoto201JSON.
+type UploadPhotoResponseObject interface {
+	// writeUploadPhotoResponse writes the response, failing before anything is written if the body can't be encoded.
+	writeUploadPhotoResponse(w http.ResponseWriter) error
+}
+
+// UploadPhoto201JSON is the 201 response of UploadPhoto.
+type UploadPhoto201JSON struct {
+	Body    UploadPhotoResponse
+	Headers http.Header
+}
+
+func (r UploadPhoto201JSON) writeUploadPhotoResponse(w http.ResponseWriter) error {
+	data, err := json.Marshal(r.Body)
+	if err != nil {
+		return err
+	}
+	for k, v := range r.Headers {
+		for _, val := range v {
+			w.Header().Add(k, val)
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+	_, _ = w.Write(data)
+	return nil
+}
+
+// Validate validates the body and the headers declared by the spec.
+func (r UploadPhoto201JSON) Validate() error {
+	if v, ok := any(r.Body).(runtime.Validator); ok {
+		if err := v.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetSessionResponseObject is a response of GetSession, implemented by GetSession200JSON.
+type GetSessionResponseObject interface {
+	// writeGetSessionResponse writes the response, failing before anything is written if the body can't be encoded.
+	writeGetSessionResponse(w http.ResponseWriter) error
+}
+
+// GetSession200JSON is the 200 response of GetSession.
+type GetSession200JSON struct {
+	Body    GetSessionResponse
+	Headers http.Header
+}
+
+func (r GetSession200JSON) writeGetSessionResponse(w http.ResponseWriter) error {
+	data, err := json.Marshal(r.Body)
+	if err != nil {
+		return err
+	}
+	for k, v := range r.Headers {
+		for _, val := range v {
+			w.Header().Add(k, val)
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	_, _ = w.Write(data)
+	return nil
+}
+
+// Validate validates the body and the headers declared by the spec.
+func (r GetSession200JSON) Validate() error {
+	if v, ok := any(r.Body).(runtime.Validator); ok {
+		if err := v.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type GetPetResponse = Pet
+
+type GetPetErrorResponse = Error
+
+type UploadPhotoResponse = Photo
+
+type GetSessionResponse = Session
+
+type StreamEventsResponse = PetEvent
+
+// GetPetServiceRequestOptions holds all parameters for the GetPet operation.
+type GetPetServiceRequestOptions struct {
+	PathParams *GetPetPath
+	// RawRequest provides access to the underlying HTTP request for custom content type handling.
+	RawRequest *http.Request
+}
+
+// Validate validates all the fields in the options.
+func (o *GetPetServiceRequestOptions) Validate() error {
+	var errors runtime.ValidationErrors
+
+	if o.PathParams != nil {
+		if v, ok := any(o.PathParams).(runtime.Validator); ok {
+			if err := v.Validate(); err != nil {
+				errors = errors.Append("PathParams", err)
+			}
+		}
+	}
+	if len(errors) == 0 {
+		return nil
+	}
+
+	return errors
+}
+
+// UploadPhotoServiceRequestOptions holds all parameters for the UploadPhoto operation.
+type UploadPhotoServiceRequestOptions struct {
+	PathParams *UploadPhotoPath
+	Body       *UploadPhotoBody
+	// RawRequest provides access to the underlying HTTP request for custom content type handling.
+	RawRequest *http.Request
+}
+
+// Validate validates all the fields in the options.
+func (o *UploadPhotoServiceRequestOptions) Validate() error {
+	var errors runtime.ValidationErrors
+
+	if o.PathParams != nil {
+		if v, ok := any(o.PathParams).(runtime.Validator); ok {
+			if err := v.Validate(); err != nil {
+				errors = errors.Append("PathParams", err)
+			}
+		}
+	}
+
+	if o.Body != nil {
+		if v, ok := any(o.Body).(runtime.Validator); ok {
+			if err := v.Validate(); err != nil {
+				errors = errors.Append("Body", err)
+			}
+		}
+	}
+	if len(errors) == 0 {
+		return nil
+	}
+
+	return errors
+}
+
+// GetSessionServiceRequestOptions holds all parameters for the GetSession operation.
+type GetSessionServiceRequestOptions struct {
+	Cookies *GetSessionCookies
+	// RawRequest provides access to the underlying HTTP request for custom content type handling.
+	RawRequest *http.Request
+}
+
+// Validate validates all the fields in the options.
+func (o *GetSessionServiceRequestOptions) Validate() error {
+	var errors runtime.ValidationErrors
+
+	if o.Cookies != nil {
+		if v, ok := any(o.Cookies).(runtime.Validator); ok {
+			if err := v.Validate(); err != nil {
+				errors = errors.Append("Cookies", err)
+			}
+		}
+	}
+	if len(errors) == 0 {
+		return nil
+	}
+
+	return errors
+}
+
+type Pet struct {
+	ID   int    `json:"id" validate:"required"`
+	Name string `json:"name" validate:"required"`
+}
+
+func (p Pet) Validate() error {
+	return runtime.ConvertValidatorError(typesValidator.Struct(p))
+}
+
+type Photo struct {
+	Caption *string `json:"caption,omitempty"`
+	Size    int     `json:"size" validate:"required"`
+}
+
+func (p Photo) Validate() error {
+	return runtime.ConvertValidatorError(typesValidator.Struct(p))
+}
+
+type Session struct {
+	ID    string  `json:"id" validate:"required"`
+	Theme *string `json:"theme,omitempty"`
+}
+
+func (s Session) Validate() error {
+	return runtime.ConvertValidatorError(typesValidator.Struct(s))
+}
+
+type PetEvent struct {
+	PetID int    `json:"petId" validate:"required"`
+	Kind  string `json:"kind" validate:"required"`
+}
+
+func (p PetEvent) Validate() error {
+	return runtime.ConvertValidatorError(typesValidator.Struct(p))
+}
+
+type Error struct {
+	Message string `json:"message" validate:"required"`
+}
+
+func (e Error) Validate() error {
+	return runtime.ConvertValidatorError(typesValidator.Struct(e))
+}
+
+func (s Error) Error() string {
+	return "unmapped client error"
+}
+
+var typesValidator *validator.Validate
+
+func init() {
+	typesValidator = validator.New(validator.WithRequiredStructEnabled())
+	runtime.RegisterCustomTypeFunc(typesValidator)
+}
@@ -0,0 +1,64 @@
+// Package api This file is generated ONCE as a starting point and will NOT be overwritten.
+// Modify it freely to add your business logic.
+// To regenerate, delete this file or set generate.handler.output.overwrite: true in config.
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yorunikakeru4/oapi-codegen-dd/v3/pkg/runtime"
+)
+
+// Service implements the ServiceInterface.
+// Add your dependencies here (database, clients, etc.)
+type Service struct {
+	pets map[int]Pet
+}
+
+// NewService creates a new Service.
+func NewService() *Service {
+	return &Service{
+		pets: map[int]Pet{1: {ID: 1, Name: "Rex"}},
+	}
+}
+
+// Ensure Service implements ServiceInterface.
+var _ ServiceInterface = (*Service)(nil)
+
+// GetPet handles GET /pets/{id}
+func (s *Service) GetPet(ctx context.Context, opts *GetPetServiceRequestOptions) (GetPetResponseObject, error) {
+	pet, ok := s.pets[opts.PathParams.ID]
+	if !ok {
+		return GetPet404JSON{Body: Error{Message: fmt.Sprintf("pet %d not found", opts.PathParams.ID)}}, nil
+	}
+	return GetPet200JSON{
+		Body:            pet,
+		ResponseHeaders: GetPetResponseHeaders{XRateLimitRemaining: 99},
+	}, nil
+}
+
+// UploadPhoto handles POST /pets/{id}/photo
+func (s *Service) UploadPhoto(ctx context.Context, opts *UploadPhotoServiceRequestOptions) (UploadPhotoResponseObject, error) {
+	data, err := opts.Body.File.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	return UploadPhoto201JSON{Body: Photo{Caption: opts.Body.Caption, Size: len(data)}}, nil
+}
+
+// GetSession handles GET /session
+func (s *Service) GetSession(ctx context.Context, opts *GetSessionServiceRequestOptions) (GetSessionResponseObject, error) {
+	return GetSession200JSON{Body: Session{ID: opts.Cookies.SessionID, Theme: opts.Cookies.Theme}}, nil
+}
+
+// StreamEvents handles GET /events
+func (s *Service) StreamEvents(ctx context.Context, events *runtime.EventSender[StreamEventsResponse]) error {
+	for i, kind := range []string{"adopted", "fed"} {
+		event := runtime.SSEEvent{ID: fmt.Sprint(i + 1), Event: kind}
+		if err := events.SendEvent(event, PetEvent{PetID: 1, Kind: kind}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package features
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/doordash-oss/oapi-codegen-dd/v3/examples/features/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yorunikakeru4/oapi-codegen-dd/v3/pkg/runtime"
+)
+
+// newClient starts a server with the generated router and returns a generated client calling it,
+// along with the requests it sends.
+func newClient(t *testing.T) (*api.Client, *recordingDoer) {
+	t.Helper()
+	server := httptest.NewServer(api.NewRouter(api.NewService()))
+	t.Cleanup(server.Close)
+
+	doer := &recordingDoer{client: server.Client()}
+	client, err := api.NewDefaultClient(server.URL, runtime.WithHTTPClient(doer))
+	require.NoError(t, err)
+	return client, doer
+}
+
+// recordingDoer sends requests with client, recording them along with their body.
+type recordingDoer struct {
+	client   *http.Client
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func (d *recordingDoer) Do(_ context.Context, req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	d.requests = append(d.requests, req)
+	d.bodies = append(d.bodies, body)
+	return d.client.Do(req)
+}
+
+func TestStrictResponses(t *testing.T) {
+	client, _ := newClient(t)
+
+	t.Run("success with typed headers", func(t *testing.T) {
+		pet, headers, err := client.GetPetWithHeaders(context.Background(), &api.GetPetRequestOptions{
+			PathParams: &api.GetPetPath{ID: 1},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, &api.Pet{ID: 1, Name: "Rex"}, pet)
+		assert.Equal(t, &api.GetPetResponseHeaders{XRateLimitRemaining: 99}, headers)
+	})
+
+	t.Run("error status", func(t *testing.T) {
+		_, err := client.GetPet(context.Background(), &api.GetPetRequestOptions{
+			PathParams: &api.GetPetPath{ID: 2},
+		})
+		var apiErr *runtime.ClientAPIError
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, http.StatusNotFound, apiErr.StatusCode())
+
+		var body api.GetPetErrorResponse
+		require.ErrorAs(t, err, &body)
+		assert.Equal(t, "pet 2 not found", body.Message)
+	})
+}
+
+func TestCookies(t *testing.T) {
+	client, doer := newClient(t)
+
+	theme := "dark"
+	session, err := client.GetSession(context.Background(), &api.GetSessionRequestOptions{
+		Cookies: &api.GetSessionCookies{SessionID: "abc123", Theme: &theme},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &api.Session{ID: "abc123", Theme: &theme}, session)
+
+	require.Len(t, doer.requests, 1)
+	cookie, err := doer.requests[0].Cookie("session_id")
+	require.NoError(t, err)
+	assert.Equal(t, "abc123", cookie.Value)
+}
+
+func TestMultipart(t *testing.T) {
+	client, doer := newClient(t)
+
+	var file runtime.File
+	file.InitFromBytes([]byte("fake image"), "rex.png")
+	caption := "Rex at the beach"
+	photo, err := client.UploadPhoto(context.Background(), &api.UploadPhotoRequestOptions{
+		PathParams: &api.UploadPhotoPath{ID: 1},
+		Body:       &api.UploadPhotoBody{Caption: &caption, File: file},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &api.Photo{Caption: &caption, Size: len("fake image")}, photo)
+
+	require.Len(t, doer.requests, 1)
+	mediaType, params, err := mime.ParseMediaType(doer.requests[0].Header.Get("Content-Type"))
+	require.NoError(t, err)
+	assert.Equal(t, "multipart/form-data", mediaType)
+
+	form, err := multipart.NewReader(bytes.NewReader(doer.bodies[0]), params["boundary"]).ReadForm(1 << 20)
+	require.NoError(t, err)
+	assert.Equal(t, []string{caption}, form.Value["caption"])
+	require.Len(t, form.File["file"], 1)
+	assert.Equal(t, "rex.png", form.File["file"][0].Filename)
+}
+
+func TestServerSentEvents(t *testing.T) {
+	client, _ := newClient(t)
+
+	stream, err := client.StreamEventsStream(context.Background())
+	require.NoError(t, err)
+
+	var (
+		events []api.PetEvent
+		ids    []string
+	)
+	for event, err := range stream.Events() {
+		require.NoError(t, err)
+		events = append(events, event)
+		ids = append(ids, stream.Event().ID)
+	}
+	assert.Equal(t, []api.PetEvent{{PetID: 1, Kind: "adopted"}, {PetID: 1, Kind: "fed"}}, events)
+	assert.Equal(t, []string{"1", "2"}, ids)
+}
@@ -12,10 +12,13 @@ package codegen
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/pb33f/libopenapi"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
 	v3high "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/utils"
 )
 
 // ParseContext holds the OpenAPI models.
@@ -27,6 +30,17 @@ type ParseContext struct {
 	Imports         []string
 	ResponseErrors  []string
 	TypeTracker     *TypeTracker
+	SecuritySchemes []SecuritySchemeDefinition
+	Servers         []ServerDefinition
+
+	// Webhooks holds the operations of webhooks and operation callbacks.
+	Webhooks []OperationDefinition
+
+	// Spec is the processed spec rendered as JSON, set when generate.embedded-spec is enabled.
+	Spec []byte
+
+	// FilterReport lists what the filter removed from the spec.
+	FilterReport FilterReport
 }
 
 type operationsCollection struct {
@@ -38,28 +52,57 @@ type operationsCollection struct {
 
 // Generate creates Go code from an OpenAPI document and a configuration in single file output.
 func Generate(docContents []byte, cfg Configuration) (GeneratedCode, error) {
+	code, _, err := GenerateWithFilterReport(docContents, cfg)
+	return code, err
+}
+
+// GenerateWithFilterReport creates Go code like Generate, also returning the report of what the filter removed from the spec.
+func GenerateWithFilterReport(docContents []byte, cfg Configuration) (GeneratedCode, FilterReport, error) {
 	cfg = cfg.WithDefaults()
 	parseCtx, errs := CreateParseContext(docContents, cfg)
 	if errs != nil {
-		return nil, fmt.Errorf("error creating parse context: %w", errs[0])
+		return nil, FilterReport{}, fmt.Errorf("error creating parse context: %w", errs[0])
 	}
 	if parseCtx == nil {
-		return nil, ErrEmptySchema
+		return nil, FilterReport{}, ErrEmptySchema
 	}
 
 	parser, err := NewParser(cfg, parseCtx)
 	if err != nil {
-		return nil, fmt.Errorf("error creating parser: %w", err)
+		return nil, FilterReport{}, fmt.Errorf("error creating parser: %w", err)
+	}
+
+	code, err := parser.Parse()
+	if err != nil {
+		return nil, FilterReport{}, err
+	}
+	return code, parseCtx.FilterReport, nil
+}
+
+// GenerateFromFile creates Go code from the OpenAPI spec at path.
+// Unlike Generate, relative external $refs are resolved against the spec directory,
+// except the ones mapped to Go packages by the import mapping.
+func GenerateFromFile(path string, cfg Configuration) (GeneratedCode, error) {
+	code, _, err := GenerateFromFileWithFilterReport(path, cfg)
+	return code, err
+}
+
+// GenerateFromFileWithFilterReport creates Go code like GenerateFromFile,
+// also returning the report of what the filter removed from the spec.
+func GenerateFromFileWithFilterReport(path string, cfg Configuration) (GeneratedCode, FilterReport, error) {
+	contents, err := loadSpecFromFile(path, cfg)
+	if err != nil {
+		return nil, FilterReport{}, err
 	}
 
-	return parser.Parse()
+	return GenerateWithFilterReport(contents, cfg)
 }
 
 // CreateParseContext creates a ParseContext from an OpenAPI contents and a ParseConfig.
 func CreateParseContext(docContents []byte, cfg Configuration) (*ParseContext, []error) {
 	cfg = cfg.WithDefaults()
 
-	doc, err := CreateDocument(docContents, cfg)
+	doc, report, err := CreateDocumentWithFilterReport(docContents, cfg)
 	if err != nil {
 		return nil, []error{fmt.Errorf("error filtering document: %w", err)}
 	}
@@ -68,6 +111,9 @@ func CreateParseContext(docContents []byte, cfg Configuration) (*ParseContext, [
 	if err != nil {
 		return nil, []error{err}
 	}
+	if res != nil {
+		res.FilterReport = report
+	}
 
 	return res, nil
 }
@@ -75,6 +121,15 @@ func CreateParseContext(docContents []byte, cfg Configuration) (*ParseContext, [
 func CreateParseContextFromDocument(doc libopenapi.Document, cfg Configuration) (*ParseContext, error) {
 	cfg = cfg.WithDefaults()
 
+	// Swagger 2.0 documents have no V3 model, load them again to get them converted
+	if info := doc.GetSpecInfo(); info != nil && info.SpecType == utils.OpenApi2 && info.SpecBytes != nil {
+		converted, err := LoadDocumentFromContents(*info.SpecBytes)
+		if err != nil {
+			return nil, err
+		}
+		doc = converted
+	}
+
 	builtModel, err := doc.BuildV3Model()
 	if err != nil {
 		return nil, fmt.Errorf("error building model: %w", err)
@@ -94,6 +149,15 @@ func CreateParseContextFromModel(model *v3high.Document, cfg Configuration) (*Pa
 		return nil, nil
 	}
 
+	naming, err := newNamer(cfg.Naming)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response envelopes and strict handlers describe the default response next to the explicit ones
+	includeDefaultResponse := (cfg.Generate.Client && cfg.Client != nil && cfg.Client.ResponseEnvelope) ||
+		(cfg.Generate.Handler != nil && cfg.Generate.Handler.Strict)
+
 	parseOptions := ParseOptions{
 		OmitDescription:        cfg.Generate.OmitDescription,
 		DefaultIntType:         cfg.Generate.DefaultIntType,
@@ -101,13 +165,17 @@ func CreateParseContextFromModel(model *v3high.Document, cfg Configuration) (*Pa
 		SkipValidation:         cfg.Generate.Validation.Skip,
 		ErrorMapping:           cfg.ErrorMapping,
 		AutoExtraTags:          cfg.Generate.AutoExtraTags,
+		IncludeDefaultResponse: includeDefaultResponse,
+		xmlRefs:                collectXMLSchemaRefs(model),
 		typeTracker:            newTypeTracker(),
+		naming:                 naming,
 		visited:                map[string]bool{},
 		model:                  model,
 	}
 
 	var (
 		operations     []OperationDefinition
+		webhooks       []OperationDefinition
 		importSchemas  []GoSchema
 		responseErrors []string
 	)
@@ -118,6 +186,24 @@ func CreateParseContextFromModel(model *v3high.Document, cfg Configuration) (*Pa
 		return nil, fmt.Errorf("error collecting component definitions: %s", err)
 	}
 
+	securitySchemes, err := collectSecuritySchemes(model, naming)
+	if err != nil {
+		return nil, fmt.Errorf("error collecting security schemes: %w", err)
+	}
+
+	servers, err := collectServers(model, naming)
+	if err != nil {
+		return nil, fmt.Errorf("error collecting servers: %w", err)
+	}
+
+	var spec []byte
+	if cfg.Generate.EmbeddedSpec {
+		spec, err = model.RenderJSON("  ")
+		if err != nil {
+			return nil, fmt.Errorf("error rendering spec: %w", err)
+		}
+	}
+
 	// collect operations
 	opColl, err := collectOperationDefinitions(model, parseOptions)
 	if err != nil {
@@ -125,7 +211,13 @@ func CreateParseContextFromModel(model *v3high.Document, cfg Configuration) (*Pa
 	}
 
 	if opColl != nil {
-		operations = opColl.operations
+		for _, op := range opColl.operations {
+			if op.Kind == OperationKindPath {
+				operations = append(operations, op)
+			} else {
+				webhooks = append(webhooks, op)
+			}
+		}
 		importSchemas = opColl.importSchemas
 		typeDefs = append(typeDefs, opColl.typeDefs...)
 		responseErrors = opColl.responseErrors
@@ -188,11 +280,118 @@ func CreateParseContextFromModel(model *v3high.Document, cfg Configuration) (*Pa
 		Imports:         importMap(imprts).GoImports(),
 		ResponseErrors:  respErrs,
 		TypeTracker:     parseOptions.typeTracker,
+		SecuritySchemes: securitySchemes,
+		Servers:         servers,
+		Webhooks:        webhooks,
+		Spec:            spec,
 	}, nil
 }
 
+// collectXMLSchemaRefs returns the references of the component schemas used by XML request and response bodies,
+// including webhooks and callbacks, which are generated with xml tags.
+// As for generation, responses declaring both JSON and XML content are decoded as JSON.
+func collectXMLSchemaRefs(model *v3high.Document) map[string]bool {
+	refs := make(map[string]bool)
+
+	var visit func(proxy *base.SchemaProxy)
+	visit = func(proxy *base.SchemaProxy) {
+		if proxy == nil {
+			return
+		}
+		if ref := proxy.GetReference(); ref != "" {
+			if refs[ref] {
+				return
+			}
+			refs[ref] = true
+		}
+		schema := proxy.Schema()
+		if schema == nil {
+			return
+		}
+		for _, property := range schema.Properties.FromOldest() {
+			visit(property)
+		}
+		for _, element := range slices.Concat(schema.AllOf, schema.AnyOf, schema.OneOf) {
+			visit(element)
+		}
+		if schema.Items != nil && schema.Items.IsA() {
+			visit(schema.Items.A)
+		}
+		if schema.AdditionalProperties != nil && schema.AdditionalProperties.IsA() {
+			visit(schema.AdditionalProperties.A)
+		}
+	}
+	visitContent := func(mediaType string, content *v3high.MediaType) {
+		if content != nil && isMediaTypeXML(mediaType) {
+			visit(content.Schema)
+		}
+	}
+	visitResponse := func(response *v3high.Response) {
+		if response == nil || response.Content == nil {
+			return
+		}
+		if _, ok := response.Content.Get("application/json"); ok {
+			return
+		}
+		if pair := response.Content.First(); pair != nil {
+			visitContent(pair.Key(), pair.Value())
+		}
+	}
+
+	var pathItems []*v3high.PathItem
+	if model.Paths != nil {
+		for _, pathItem := range model.Paths.PathItems.FromOldest() {
+			pathItems = append(pathItems, pathItem)
+		}
+	}
+	for _, pathItem := range model.Webhooks.FromOldest() {
+		pathItems = append(pathItems, pathItem)
+	}
+
+	// Callback path items are appended while iterating
+	for i := 0; i < len(pathItems); i++ {
+		if pathItems[i] == nil {
+			continue
+		}
+		for _, operation := range pathItems[i].GetOperations().FromOldest() {
+			if operation.RequestBody != nil && operation.RequestBody.Content != nil {
+				if pair := operation.RequestBody.Content.First(); pair != nil {
+					visitContent(pair.Key(), pair.Value())
+				}
+			}
+			if operation.Responses != nil {
+				for _, response := range operation.Responses.Codes.FromOldest() {
+					visitResponse(response)
+				}
+				visitResponse(operation.Responses.Default)
+			}
+			for _, callback := range operation.Callbacks.FromOldest() {
+				if callback == nil {
+					continue
+				}
+				for _, pathItem := range callback.Expression.FromOldest() {
+					pathItems = append(pathItems, pathItem)
+				}
+			}
+		}
+	}
+
+	return refs
+}
+
 func collectOperationDefinitions(model *v3high.Document, options ParseOptions) (*operationsCollection, error) {
-	if model.Paths == nil || model.Paths.PathItems == nil {
+	var sources []operationSource
+	if model.Paths != nil && model.Paths.PathItems != nil {
+		for path, pathItem := range model.Paths.PathItems.FromOldest() {
+			sources = append(sources, operationSource{path: path, pathItem: pathItem})
+		}
+	}
+	if model.Webhooks != nil {
+		for name, pathItem := range model.Webhooks.FromOldest() {
+			sources = append(sources, operationSource{path: name, pathItem: pathItem, kind: OperationKindWebhook})
+		}
+	}
+	if len(sources) == 0 {
 		return nil, nil
 	}
 
@@ -206,7 +405,20 @@ func collectOperationDefinitions(model *v3high.Document, options ParseOptions) (
 	// Track seen operation IDs to deduplicate inline before generating param types
 	seenOperationIDs := make(map[string]int)
 
-	for path, pathItem := range model.Paths.PathItems.FromOldest() {
+	// Callback sources are appended while operations are processed, as they need the parent operation ID.
+	for i := 0; i < len(sources); i++ {
+		src := sources[i]
+		path, pathItem := src.path, src.pathItem
+		if pathItem == nil {
+			continue
+		}
+
+		// Document-level security doesn't apply to webhooks and callbacks, as they're served by the API consumer.
+		var globalSecurity []*base.SecurityRequirement
+		if src.kind == OperationKindPath {
+			globalSecurity = model.Security
+		}
+
 		// These are parameters defined for all methods on a given path. They
 		// are shared by all methods.
 		globalParams, err := describeOperationParameters(pathItem.Parameters, options.WithPath(nil))
@@ -217,10 +429,11 @@ func collectOperationDefinitions(model *v3high.Document, options ParseOptions) (
 		for method, operation := range pathItem.GetOperations().FromOldest() {
 			var (
 				headerDef     *RequestParametersDefinition
+				cookiesDef    *RequestParametersDefinition
 				pathParamsDef *TypeDefinition
 			)
 
-			operationID, err := createOperationID(method, path, operation.OperationId)
+			operationID, err := options.namer().createOperationID(method, src.operationIDPath(), operation.OperationId)
 			if err != nil {
 				return nil, fmt.Errorf("error creating operation ID: %w", err)
 			}
@@ -283,6 +496,16 @@ func collectOperationDefinitions(model *v3high.Document, options ParseOptions) (
 				}
 			}
 
+			cookieParams := filterParameterDefinitionByType(allParams, "cookie")
+			cookieParamsDef, cookieDefs, cookieSchemas := generateParamsTypes(cookieParams, operationID+"Cookies", options)
+			if cookieParamsDef != nil {
+				cookiesDef = cookieParamsDef
+				typeDefs = append(typeDefs, cookieDefs...)
+				if len(cookieSchemas) > 0 {
+					importSchemas = append(importSchemas, cookieSchemas...)
+				}
+			}
+
 			// Process Request Body
 			bodyDefinition, bodyTypeDef, err := createBodyDefinition(operationID, operation.RequestBody, options)
 			if err != nil {
@@ -315,8 +538,9 @@ func collectOperationDefinitions(model *v3high.Document, options ParseOptions) (
 				}
 			}
 
-			// Parse x-mcp extension if present
+			// Parse x-mcp and x-go-stream extensions if present
 			var mcpExt *MCPExtension
+			streaming := isStreamingResponse(response.Success)
 			if operation.Extensions != nil {
 				extensions := extractExtensions(operation.Extensions)
 				if mcpValue, ok := extensions[extMCP]; ok {
@@ -325,21 +549,57 @@ func collectOperationDefinitions(model *v3high.Document, options ParseOptions) (
 						return nil, fmt.Errorf("error parsing x-mcp extension for %s: %w", operationID, err)
 					}
 				}
+				if streamValue, ok := extensions[extGoStream]; ok {
+					streaming, err = parseBooleanValue(streamValue)
+					if err != nil {
+						return nil, fmt.Errorf("error parsing %s extension for %s: %w", extGoStream, operationID, err)
+					}
+				}
+			}
+			if response.Success != nil && response.Success.IsEventStream {
+				streaming = true
+			}
+
+			operationTags := operation.Tags
+			if len(operationTags) == 0 {
+				operationTags = src.tags
+			}
+
+			if operation.Callbacks != nil {
+				for name, callback := range operation.Callbacks.FromOldest() {
+					if callback == nil || callback.Expression == nil {
+						continue
+					}
+					for expression, cbPathItem := range callback.Expression.FromOldest() {
+						sources = append(sources, operationSource{
+							path:     expression,
+							pathItem: cbPathItem,
+							kind:     OperationKindCallback,
+							idPath:   operationID + "/" + name,
+							tags:     operationTags,
+						})
+					}
+				}
 			}
 
 			operations = append(operations, OperationDefinition{
 				ID:          operationID,
+				Kind:        src.kind,
 				Summary:     operation.Summary,
 				Description: operation.Description,
 				// https://datatracker.ietf.org/doc/html/rfc7231
 				Method:     strings.ToUpper(method),
 				Path:       path,
+				Tags:       operationTags,
 				PathParams: pathParamsDef,
 				Header:     headerDef,
+				Cookies:    cookiesDef,
 				Query:      queryParamsDef,
 				Response:   response,
 				Body:       bodyDefinition,
 				MCP:        mcpExt,
+				Streaming:  streaming,
+				Security:   describeSecurityRequirements(operation, globalSecurity),
 			})
 		}
 	}
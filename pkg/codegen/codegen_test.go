@@ -11,11 +11,20 @@
 package codegen
 
 import (
+	"bytes"
+	"compress/gzip"
 	"embed"
+	"encoding/base64"
+	"encoding/json"
 	"go/format"
+	"io"
 	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
 
+	"github.com/pb33f/libopenapi"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -471,16 +480,16 @@ func TestRawContentTypesGenerateByteSlice(t *testing.T) {
 
 	code := codes.GetCombined()
 
-	// Raw content types (YAML, XML) should generate []byte aliases
+	// Raw content types (YAML) should generate []byte aliases
 	assert.Contains(t, code, "type GetYamlConfigResponse = []byte")
-	assert.Contains(t, code, "type GetXMLDataResponse = []byte")
 
-	// JSON content type should still generate a struct
+	// JSON and XML content types should still generate a struct
 	assert.Contains(t, code, "type GetJSONDataResponse struct")
+	assert.Contains(t, code, "type GetXMLDataResponse struct")
 
 	// Client code for raw types should use direct byte conversion, not json.Unmarshal
 	assert.Contains(t, code, "result := GetYamlConfigResponse(bodyBytes)")
-	assert.Contains(t, code, "result := GetXMLDataResponse(bodyBytes)")
+	assert.Contains(t, code, "xml.Unmarshal(bodyBytes, target)")
 
 	// Client code for JSON should still use json.Unmarshal
 	assert.Contains(t, code, "json.Unmarshal(bodyBytes, target)")
@@ -489,3 +498,1218 @@ func TestRawContentTypesGenerateByteSlice(t *testing.T) {
 	_, err = format.Source([]byte(code))
 	require.NoError(t, err, "Generated code should compile without syntax errors")
 }
+
+func TestSecuritySchemes(t *testing.T) {
+	cfg := Configuration{
+		PackageName: "api",
+		Output: &Output{
+			UseSingleFile: true,
+		},
+		Generate: &GenerateOptions{
+			Client: true,
+			Handler: &HandlerOptions{
+				Kind: HandlerKindChi,
+			},
+		},
+	}
+
+	t.Run("parse context", func(t *testing.T) {
+		ctx, errs := CreateParseContext([]byte(readTestdata(t, "security-schemes.yml")), cfg)
+		require.Nil(t, errs)
+
+		var schemeNames []string
+		for _, scheme := range ctx.SecuritySchemes {
+			schemeNames = append(schemeNames, scheme.Name)
+		}
+		// UnusedAuth is not referenced by any operation and gets pruned
+		assert.Equal(t, []string{"ApiKeyAuth", "BasicAuth", "BearerAuth", "PetstoreAuth"}, schemeNames)
+		assert.Equal(t, []string{"read:pets", "write:pets"}, ctx.SecuritySchemes[3].Scopes)
+
+		ops := map[string]OperationDefinition{}
+		for _, op := range ctx.Operations {
+			ops[op.ID] = op
+		}
+
+		// Global security applies when the operation has none
+		assert.Equal(t, []SecurityRequirement{
+			{Schemes: []SecurityRequirementScheme{{Name: "BearerAuth"}}},
+		}, ops["ListPets"].Security)
+
+		// Operation security overrides the global one
+		assert.Equal(t, []SecurityRequirement{
+			{Schemes: []SecurityRequirementScheme{
+				{Name: "ApiKeyAuth"},
+				{Name: "PetstoreAuth", Scopes: []string{"write:pets", "read:pets"}},
+			}},
+			{Schemes: []SecurityRequirementScheme{{Name: "BasicAuth"}}},
+		}, ops["CreatePet"].Security)
+
+		// Explicit empty security disables authentication
+		assert.Nil(t, ops["Health"].Security)
+	})
+
+	t.Run("generated code", func(t *testing.T) {
+		codes, err := Generate([]byte(readTestdata(t, "security-schemes.yml")), cfg)
+		require.NoError(t, err)
+
+		code := codes.GetCombined()
+
+		assert.Contains(t, code, `return runtime.WithAPIKey(runtime.APIKeyInHeader, "X-API-Key", apiKey)`)
+		assert.Contains(t, code, "func WithBasicAuth(username, password string) runtime.APIClientOption")
+		assert.Contains(t, code, "func WithBearerAuth(token string) runtime.APIClientOption")
+		assert.Contains(t, code, "func WithPetstoreAuth(src runtime.TokenSource) runtime.APIClientOption")
+		assert.NotContains(t, code, "WithUnusedAuth")
+
+		assert.Contains(t, code, "type SecurityValidator interface")
+		assert.Contains(t, code, "func WithSecurityValidator(v SecurityValidator) RouterOption")
+		assert.Contains(t, code, "func WithSecurityDisabled() RouterOption")
+		assert.Contains(t, code, `a.securityValidator.ValidateSecurity(ctx, r, "ListPets"`)
+		assert.Contains(t, code, "if !a.securityDisabled && a.securityValidator != nil {")
+		assert.Contains(t, code, "If not set, requests to these operations are served without checking them.")
+		assert.NotContains(t, code, "no security validator configured")
+		assert.Contains(t, code, `{"ApiKeyAuth": {}, "PetstoreAuth": {"write:pets", "read:pets"}},`)
+		assert.NotContains(t, code, `a.securityValidator.ValidateSecurity(ctx, r, "Health"`)
+
+		_, err = format.Source([]byte(code))
+		require.NoError(t, err, "Generated code should compile without syntax errors")
+	})
+
+	t.Run("require security validator", func(t *testing.T) {
+		requireCfg := cfg
+		requireCfg.Generate = &GenerateOptions{
+			Handler: &HandlerOptions{
+				Kind:                     HandlerKindChi,
+				RequireSecurityValidator: true,
+			},
+		}
+
+		codes, err := Generate([]byte(readTestdata(t, "security-schemes.yml")), requireCfg)
+		require.NoError(t, err)
+
+		code := codes.GetCombined()
+
+		assert.Contains(t, code, `Message:     "no security validator configured",`)
+		assert.Contains(t, code, "unless WithSecurityDisabled is used.")
+		assert.NotContains(t, code, "a.securityValidator != nil")
+
+		_, err = format.Source([]byte(code))
+		require.NoError(t, err, "Generated code should compile without syntax errors")
+	})
+}
+
+func TestWebhooksAndCallbacks(t *testing.T) {
+	cfg := Configuration{
+		PackageName: "api",
+		Output: &Output{
+			UseSingleFile: true,
+		},
+		Generate: &GenerateOptions{
+			Client: true,
+			Handler: &HandlerOptions{
+				Kind: HandlerKindChi,
+			},
+		},
+	}
+
+	t.Run("parse context", func(t *testing.T) {
+		ctx, errs := CreateParseContext([]byte(readTestdata(t, "webhooks-callbacks.yml")), cfg)
+		require.Nil(t, errs)
+
+		require.Len(t, ctx.Operations, 1)
+		assert.Equal(t, "CreateSubscription", ctx.Operations[0].ID)
+
+		require.Len(t, ctx.Webhooks, 2)
+		assert.Equal(t, "PetCreated", ctx.Webhooks[0].ID)
+		assert.Equal(t, OperationKindWebhook, ctx.Webhooks[0].Kind)
+		assert.Equal(t, "pet.created", ctx.Webhooks[0].Path)
+		assert.NotNil(t, ctx.Webhooks[0].Header)
+
+		assert.Equal(t, "PostCreateSubscriptionOnEvent", ctx.Webhooks[1].ID)
+		assert.Equal(t, OperationKindCallback, ctx.Webhooks[1].Kind)
+		assert.Equal(t, "{$request.body#/callbackUrl}", ctx.Webhooks[1].Path)
+	})
+
+	t.Run("generated code", func(t *testing.T) {
+		codes, err := Generate([]byte(readTestdata(t, "webhooks-callbacks.yml")), cfg)
+		require.NoError(t, err)
+
+		code := codes.GetCombined()
+
+		// Schemas referenced only by webhooks and callbacks are kept
+		assert.Contains(t, code, "type Pet struct")
+		assert.Contains(t, code, "type Event struct")
+
+		// Sending side
+		assert.Contains(t, code, "type WebhookSender struct")
+		assert.Contains(t, code, "func (c *WebhookSender) PetCreated(ctx context.Context, targetURL string, options *PetCreatedRequestOptions, reqEditors ...runtime.RequestEditorFn) (*PetCreatedResponse, error)")
+		assert.Contains(t, code, "func (c *WebhookSender) PostCreateSubscriptionOnEvent(ctx context.Context, targetURL string")
+		assert.NotContains(t, code, "func (c *Client) PetCreated(")
+
+		// Receiving side
+		assert.Contains(t, code, "type WebhooksInterface interface")
+		assert.Contains(t, code, "PetCreated(ctx context.Context, opts *PetCreatedServiceRequestOptions) (*PetCreatedResponseData, error)")
+		assert.Contains(t, code, "func (a *WebhookHTTPAdapter) PetCreated(w http.ResponseWriter, r *http.Request)")
+		assert.Contains(t, code, "func (a *WebhookHTTPAdapter) PostCreateSubscriptionOnEvent(w http.ResponseWriter, r *http.Request)")
+		assert.NotContains(t, code, `r.Method("POST", "pet.created"`)
+
+		_, err = format.Source([]byte(code))
+		require.NoError(t, err, "Generated code should compile without syntax errors")
+	})
+
+	t.Run("webhooks only", func(t *testing.T) {
+		codes, err := Generate([]byte(readTestdata(t, "webhooks-with-examples.yml")), cfg)
+		require.NoError(t, err)
+
+		code := codes.GetCombined()
+		assert.Contains(t, code, "type WebhookSender struct")
+		assert.Contains(t, code, "type WebhookHTTPAdapter struct")
+		assert.NotContains(t, code, "func NewRouter(")
+		assert.NotContains(t, code, "type Client struct")
+	})
+}
+
+func TestResponseEnvelope(t *testing.T) {
+	cfg := Configuration{
+		PackageName: "api",
+		Output: &Output{
+			UseSingleFile: true,
+		},
+		Generate: &GenerateOptions{
+			Client: true,
+		},
+		Client: &Client{
+			ResponseEnvelope: true,
+		},
+	}
+
+	t.Run("parse context", func(t *testing.T) {
+		ctx, errs := CreateParseContext([]byte(readTestdata(t, "response-envelope.yml")), cfg)
+		require.Nil(t, errs)
+		require.Len(t, ctx.Operations, 2)
+
+		createPet := ctx.Operations[0].Response
+		var fields []string
+		for _, rcd := range createPet.WithContent() {
+			fields = append(fields, rcd.EnvelopeField())
+		}
+		assert.Equal(t, []string{"JSON200", "JSON202", "JSON409", "JSON422"}, fields)
+		require.NotNil(t, createPet.Default)
+		assert.Equal(t, "Error", createPet.Default.ResponseName)
+
+		deletePet := ctx.Operations[1].Response
+		require.NotNil(t, deletePet.Default)
+		assert.Equal(t, "DeletePetErrorResponse", deletePet.Default.ResponseName)
+		assert.Empty(t, deletePet.WithContent())
+	})
+
+	t.Run("generated code", func(t *testing.T) {
+		codes, err := Generate([]byte(readTestdata(t, "response-envelope.yml")), cfg)
+		require.NoError(t, err)
+
+		code := codes.GetCombined()
+		assert.Contains(t, code, "CreatePetWithResponse(ctx context.Context, options *CreatePetRequestOptions, reqEditors ...runtime.RequestEditorFn) (*CreatePetResponseEnvelope, error)")
+		assert.Contains(t, code, "JSON202 *CreatePetResponseJSON")
+		assert.Contains(t, code, "JSON409 *CreatePetErrorResponse")
+		assert.Contains(t, code, "JSON422 *CreatePetErrorResponseJSON")
+		assert.Contains(t, code, "Default *Error")
+		assert.Contains(t, code, "Response *runtime.Response")
+		assert.Contains(t, code, "res.JSON422 = target")
+
+		// Regular methods are kept
+		assert.Contains(t, code, "func (c *Client) CreatePet(ctx context.Context, options *CreatePetRequestOptions, reqEditors ...runtime.RequestEditorFn) (*CreatePetResponseJSON, error)")
+
+		_, err = format.Source([]byte(code))
+		require.NoError(t, err, "Generated code should compile without syntax errors")
+	})
+
+	t.Run("disabled by default", func(t *testing.T) {
+		cfg := cfg
+		cfg.Client = nil
+
+		codes, err := Generate([]byte(readTestdata(t, "response-envelope.yml")), cfg)
+		require.NoError(t, err)
+
+		code := codes.GetCombined()
+		assert.NotContains(t, code, "ResponseEnvelope")
+		assert.NotContains(t, code, "WithResponse")
+	})
+}
+
+func TestCookieParams(t *testing.T) {
+	cfg := Configuration{
+		PackageName: "api",
+		Output: &Output{
+			UseSingleFile: true,
+		},
+		Generate: &GenerateOptions{
+			Client: true,
+			Handler: &HandlerOptions{
+				Kind: HandlerKindChi,
+			},
+		},
+	}
+
+	t.Run("parse context", func(t *testing.T) {
+		ctx, errs := CreateParseContext([]byte(readTestdata(t, "cookie-params.yml")), cfg)
+		require.Nil(t, errs)
+		require.Len(t, ctx.Operations, 1)
+
+		op := ctx.Operations[0]
+		require.NotNil(t, op.Cookies)
+		assert.Equal(t, "GetSessionCookies", op.Cookies.Name)
+
+		var names []string
+		for _, param := range op.Cookies.Params {
+			names = append(names, param.ParamName)
+		}
+		assert.ElementsMatch(t, []string{"session_id", "page", "theme", "ids", "ratio"}, names)
+
+		require.NotNil(t, op.Header)
+		assert.Len(t, op.Header.Params, 1)
+	})
+
+	t.Run("generated code", func(t *testing.T) {
+		codes, err := Generate([]byte(readTestdata(t, "cookie-params.yml")), cfg)
+		require.NoError(t, err)
+
+		code := codes.GetCombined()
+		assert.Contains(t, code, "type GetSessionCookies struct")
+		assert.Contains(t, code, "Cookies *GetSessionCookies")
+		assert.Contains(t, code, "func (o *GetSessionRequestOptions) GetCookies() (map[string]any, error)")
+		assert.Contains(t, code, `r.Cookie("session_id")`)
+		assert.Contains(t, code, `runtime.ParseStringSlice[int](strings.Split(cookie.Value, ","))`)
+		assert.Contains(t, code, `ParamLocation: "cookie"`)
+		assert.Contains(t, code, "opts.Cookies = cookieParams")
+
+		_, err = format.Source([]byte(code))
+		require.NoError(t, err, "Generated code should compile without syntax errors")
+	})
+}
+
+func TestGenerateFromFile(t *testing.T) {
+	cfg := Configuration{
+		PackageName: "api",
+		Output: &Output{
+			UseSingleFile: true,
+		},
+		Generate: &GenerateOptions{
+			Client: true,
+		},
+	}
+
+	t.Run("resolves relative external refs", func(t *testing.T) {
+		codes, err := GenerateFromFile("testdata/multi-file/api.yml", cfg)
+		require.NoError(t, err)
+
+		code := codes.GetCombined()
+		// Named after the referenced definition
+		assert.Contains(t, code, "type Pet struct")
+		// Named after the file when the whole file is referenced
+		assert.Contains(t, code, "type Owner struct")
+		assert.Contains(t, code, "Owner *Owner `json:\"owner,omitempty\"`")
+		assert.Contains(t, code, "type GetPetResponse = Pet")
+		assert.Contains(t, code, "type ListPetsResponse []Pet")
+		// Path items referenced from other files
+		assert.Contains(t, code, "func (c *Client) ListPets(")
+
+		_, err = format.Source([]byte(code))
+		require.NoError(t, err, "Generated code should compile without syntax errors")
+	})
+
+	t.Run("mapped external refs are not loaded", func(t *testing.T) {
+		mapped := cfg
+		mapped.ImportMapping = map[string]string{"./schemas/pet.yml": "example.com/app/pets"}
+
+		codes, err := GenerateFromFile("testdata/multi-file/api.yml", mapped)
+		require.NoError(t, err)
+
+		code := codes.GetCombined()
+		assert.Contains(t, code, "type GetPetResponse = pets.Pet")
+		assert.NotContains(t, code, "type Pet struct")
+		// Only referenced from the mapped file
+		assert.NotContains(t, code, "type Owner struct")
+	})
+
+	t.Run("single file spec is unchanged", func(t *testing.T) {
+		contents, err := LoadSpecFromFile("testdata/cookie-params.yml")
+		require.NoError(t, err)
+		assert.Equal(t, readTestdata(t, "cookie-params.yml"), string(contents))
+	})
+
+	t.Run("spec files", func(t *testing.T) {
+		dir, err := filepath.Abs("testdata/multi-file")
+		require.NoError(t, err)
+
+		files, err := SpecFiles("testdata/multi-file/api.yml", cfg)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{
+			filepath.Join(dir, "api.yml"),
+			filepath.Join(dir, "paths", "pets.yml"),
+			filepath.Join(dir, "schemas", "pet.yml"),
+			filepath.Join(dir, "schemas", "owner.yml"),
+		}, files)
+
+		mapped := cfg
+		mapped.ImportMapping = map[string]string{"./schemas/pet.yml": "example.com/app/pets"}
+		files, err = SpecFiles("testdata/multi-file/api.yml", mapped)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{
+			filepath.Join(dir, "api.yml"),
+			filepath.Join(dir, "paths", "pets.yml"),
+		}, files)
+	})
+
+	t.Run("swagger 2.0 spec is unchanged", func(t *testing.T) {
+		contents, err := LoadSpecFromFile("testdata/swagger2.yml")
+		require.NoError(t, err)
+		assert.Equal(t, readTestdata(t, "swagger2.yml"), string(contents))
+	})
+
+	t.Run("swagger 2.0 external refs are rejected", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "api.yml")
+		require.NoError(t, os.WriteFile(path, []byte(`swagger: "2.0"
+info:
+  title: API
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: OK
+          schema:
+            $ref: "./definitions.yml#/Pet"
+        default:
+          description: Error
+          schema:
+            $ref: "#/definitions/Error"
+definitions:
+  Error:
+    type: object
+`), 0o600))
+
+		_, err := LoadSpecFromFile(path)
+		require.EqualError(t, err, "external references are not supported in Swagger 2.0 specs, "+
+			"convert the spec to OpenAPI 3 or bundle it first: ./definitions.yml#/Pet")
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := GenerateFromFile("testdata/does-not-exist.yml", cfg)
+		require.Error(t, err)
+	})
+
+	t.Run("invalid spec", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "api.yml")
+		require.NoError(t, os.WriteFile(path, []byte("openapi: [3.0.0\n"), 0o600))
+
+		_, err := LoadSpecFromFile(path)
+		require.ErrorContains(t, err, "error loading spec")
+	})
+
+	t.Run("broken external ref", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "api.yml")
+		require.NoError(t, os.WriteFile(path, []byte(`openapi: 3.0.0
+info:
+  title: API
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: "./missing.yml#/Pet"
+`), 0o600))
+
+		_, err := LoadSpecFromFile(path)
+		require.ErrorContains(t, err, "error building model")
+	})
+}
+
+func TestSwagger2(t *testing.T) {
+	cfg := Configuration{
+		PackageName: "api",
+		Output: &Output{
+			UseSingleFile: true,
+		},
+		Generate: &GenerateOptions{
+			Client: true,
+			Handler: &HandlerOptions{
+				Kind: HandlerKindChi,
+			},
+		},
+	}
+
+	t.Run("conversion", func(t *testing.T) {
+		converted, warnings, err := ConvertSwagger2([]byte(readTestdata(t, "swagger2.yml")))
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{
+			`#/paths/~1pets/get/parameters: collectionFormat "tsv" of parameter "status" has no OpenAPI 3 equivalent, the default style is used`,
+			"#/paths/~1login/post/schemes: operation schemes are not supported, the global servers apply",
+		}, warnings)
+
+		doc, err := LoadDocumentFromContents(converted)
+		require.NoError(t, err)
+		model, err := doc.BuildV3Model()
+		require.NoError(t, err)
+
+		assert.Equal(t, "3.0.3", model.Model.Version)
+		require.Len(t, model.Model.Servers, 1)
+		assert.Equal(t, "https://petstore.example.com/v1", model.Model.Servers[0].URL)
+
+		schemes := model.Model.Components.SecuritySchemes
+		basic, _ := schemes.Get("basicAuth")
+		require.NotNil(t, basic)
+		assert.Equal(t, "http", basic.Type)
+		assert.Equal(t, "basic", basic.Scheme)
+		oauth, _ := schemes.Get("petstoreAuth")
+		require.NotNil(t, oauth)
+		require.NotNil(t, oauth.Flows.AuthorizationCode)
+		assert.Equal(t, "https://petstore.example.com/oauth/token", oauth.Flows.AuthorizationCode.TokenUrl)
+
+		pets := model.Model.Paths.PathItems.GetOrZero("/pets")
+		require.NotNil(t, pets)
+		tags := pets.Get.Parameters[1]
+		assert.Equal(t, "form", tags.Style)
+		assert.True(t, *tags.Explode)
+		_, ok := pets.Post.RequestBody.Content.Get("application/json")
+		assert.True(t, ok)
+
+		upload := model.Model.Paths.PathItems.GetOrZero("/pets/{petId}/photo")
+		require.NotNil(t, upload)
+		_, ok = upload.Post.RequestBody.Content.Get("multipart/form-data")
+		assert.True(t, ok)
+
+		login := model.Model.Paths.PathItems.GetOrZero("/login")
+		require.NotNil(t, login)
+		_, ok = login.Post.RequestBody.Content.Get("application/x-www-form-urlencoded")
+		assert.True(t, ok)
+	})
+
+	t.Run("generated code", func(t *testing.T) {
+		codes, err := Generate([]byte(readTestdata(t, "swagger2.yml")), cfg)
+		require.NoError(t, err)
+
+		code := codes.GetCombined()
+		assert.Contains(t, code, "type Pet struct")
+		assert.Contains(t, code, "Owner *string `json:\"owner,omitempty\"`")
+		assert.Contains(t, code, "type CreatePetBody = NewPet")
+		assert.Contains(t, code, "File    runtime.File `json:\"file\" validate:\"required\"`")
+		assert.Contains(t, code, "Username string `json:\"username\" validate:\"required\"`")
+		assert.Contains(t, code, "SecuritySchemePetstoreAuth = \"petstoreAuth\"")
+		assert.Contains(t, code, "func (c *Client) UploadPhoto(")
+
+		_, err = format.Source([]byte(code))
+		require.NoError(t, err, "Generated code should compile without syntax errors")
+	})
+
+	t.Run("parse context from unconverted document", func(t *testing.T) {
+		doc, err := libopenapi.NewDocument([]byte(readTestdata(t, "swagger2.yml")))
+		require.NoError(t, err)
+
+		ctx, err := CreateParseContextFromDocument(doc, cfg)
+		require.NoError(t, err)
+		assert.Len(t, ctx.Operations, 4)
+	})
+
+	t.Run("from file", func(t *testing.T) {
+		codes, err := GenerateFromFile("testdata/swagger2.yml", cfg)
+		require.NoError(t, err)
+		assert.Contains(t, codes.GetCombined(), "type Token struct")
+	})
+
+	t.Run("OpenAPI 3 is unchanged", func(t *testing.T) {
+		contents := []byte(readTestdata(t, "cookie-params.yml"))
+		converted, warnings, err := ConvertSwagger2(contents)
+		require.NoError(t, err)
+		assert.Empty(t, warnings)
+		assert.Equal(t, contents, converted)
+	})
+}
+
+func TestUnionValidation(t *testing.T) {
+	cfg := Configuration{
+		PackageName: "api",
+		Output: &Output{
+			UseSingleFile: true,
+		},
+		Generate: &GenerateOptions{
+			Client: true,
+		},
+	}
+
+	codes, err := Generate([]byte(readTestdata(t, "union-validation.yml")), cfg)
+	require.NoError(t, err)
+	code := codes.GetCombined()
+
+	t.Run("discriminator selects the branch", func(t *testing.T) {
+		assert.Contains(t, code, "discriminator, err := p.discriminator(p.union)")
+		assert.Contains(t, code, `case "gift_card":
+		_, err = p.AsValidatedGiftCard()
+		return runtime.ConvertValidatorError(err)`)
+		assert.Contains(t, code, `return runtime.NewValidationErrorsFromString("type", "unknown discriminator value: "+discriminator)`)
+	})
+
+	t.Run("oneOf without discriminator", func(t *testing.T) {
+		assert.Contains(t, code, `func (i *Identifier_OneOf) Validate() error {
+	if len(i.union) == 0 || string(i.union) == "null" {
+		return nil
+	}
+	return runtime.ValidateOneOf(
+		runtime.UnionVariant{Name: "String", Validate: func() error {
+			_, err := i.AsValidatedString()
+			return err
+		}},`)
+	})
+
+	t.Run("anyOf", func(t *testing.T) {
+		assert.Contains(t, code, `func (c *Contact_AnyOf) Validate() error {
+	if len(c.union) == 0 || string(c.union) == "null" {
+		return nil
+	}
+	return runtime.ValidateAnyOf(`)
+	})
+
+	assert.NotContains(t, code, "Validation is not supported for unions")
+
+	_, err = format.Source([]byte(code))
+	require.NoError(t, err, "Generated code should compile without syntax errors")
+}
+
+func TestServers(t *testing.T) {
+	cfg := Configuration{
+		PackageName: "api",
+		Output: &Output{
+			UseSingleFile: true,
+		},
+		Generate: &GenerateOptions{
+			Client: true,
+		},
+	}
+
+	t.Run("parse context", func(t *testing.T) {
+		ctx, errs := CreateParseContext([]byte(readTestdata(t, "servers.yml")), cfg)
+		require.Nil(t, errs)
+		require.Len(t, ctx.Servers, 4)
+
+		var names []string
+		for _, server := range ctx.Servers {
+			names = append(names, server.GoName)
+		}
+		assert.Equal(t, []string{"Production", "Staging", "Local", "V1"}, names)
+
+		production := ctx.Servers[0]
+		assert.Equal(t, "https://us.api.example.com/v1", production.DefaultURL())
+		require.Len(t, production.Variables, 2)
+		assert.Equal(t, "region", production.Variables[0].ParamName)
+		assert.Equal(t, []string{"us", "eu", "ap"}, production.Variables[0].Enum)
+	})
+
+	t.Run("generated code", func(t *testing.T) {
+		codes, err := Generate([]byte(readTestdata(t, "servers.yml")), cfg)
+		require.NoError(t, err)
+
+		code := codes.GetCombined()
+		assert.Contains(t, code, `ServerProduction = "https://us.api.example.com/v1"`)
+		assert.Contains(t, code, `ServerLocal   = "http://localhost:8080"`)
+		assert.Contains(t, code, "func NewServerProductionURL(region, version string) (string, error)")
+		assert.Contains(t, code, `case "us", "eu", "ap":`)
+		assert.Contains(t, code, "func NewServerLocalURL(port string) (string, error)")
+		assert.NotContains(t, code, "func NewServerStagingURL(")
+		assert.Contains(t, code, "func NewDefaultClientForStaging(opts ...runtime.APIClientOption) (*Client, error)")
+
+		_, err = format.Source([]byte(code))
+		require.NoError(t, err, "Generated code should compile without syntax errors")
+	})
+
+	t.Run("default not in enum", func(t *testing.T) {
+		spec := `
+openapi: 3.0.0
+info:
+  title: Servers
+  version: 1.0.0
+servers:
+  - url: https://{region}.example.com
+    variables:
+      region:
+        default: mars
+        enum: [us, eu]
+paths: {}
+`
+		_, errs := CreateParseContext([]byte(spec), cfg)
+		require.NotEmpty(t, errs)
+		assert.Contains(t, errs[0].Error(), `default "mars" of variable "region" is not in its enum`)
+	})
+}
+
+func TestEmbeddedSpec(t *testing.T) {
+	cfg := Configuration{
+		PackageName: "api",
+		Output: &Output{
+			UseSingleFile: true,
+		},
+		Generate: &GenerateOptions{
+			EmbeddedSpec: true,
+			Handler: &HandlerOptions{
+				SpecPath: "/openapi.json",
+			},
+		},
+		Filter: FilterConfig{
+			Exclude: FilterParamsConfig{
+				Paths: []string{"/internal/stats"},
+			},
+		},
+	}
+
+	t.Run("parse context", func(t *testing.T) {
+		ctx, errs := CreateParseContext([]byte(readTestdata(t, "embedded-spec.yml")), cfg)
+		require.Nil(t, errs)
+
+		var spec map[string]any
+		require.NoError(t, json.Unmarshal(ctx.Spec, &spec))
+		assert.Contains(t, spec["paths"], "/pets")
+		assert.NotContains(t, spec["paths"], "/internal/stats")
+		assert.NotContains(t, string(ctx.Spec), "Stats")
+	})
+
+	t.Run("encoded spec", func(t *testing.T) {
+		spec := []byte(strings.Repeat(`{"openapi":"3.0.0"}`, 100))
+		lines, err := encodeEmbeddedSpec(spec)
+		require.NoError(t, err)
+
+		compressed, err := base64.StdEncoding.DecodeString(strings.Join(lines, ""))
+		require.NoError(t, err)
+		zr, err := gzip.NewReader(bytes.NewReader(compressed))
+		require.NoError(t, err)
+		decoded, err := io.ReadAll(zr)
+		require.NoError(t, err)
+		assert.Equal(t, spec, decoded)
+	})
+
+	t.Run("generated code", func(t *testing.T) {
+		codes, err := Generate([]byte(readTestdata(t, "embedded-spec.yml")), cfg)
+		require.NoError(t, err)
+
+		code := codes.GetCombined()
+		assert.Contains(t, code, "var openAPISpec = []string{")
+		assert.Contains(t, code, "func GetOpenAPISpec() ([]byte, error)")
+		assert.Contains(t, code, "func (a *HTTPAdapter) ServeOpenAPISpec(w http.ResponseWriter, r *http.Request)")
+		assert.Contains(t, code, `r.Method("GET", "/openapi.json", http.HandlerFunc(adapter.ServeOpenAPISpec))`)
+		assert.Contains(t, code, "func NewRequestValidationMiddleware(errHandler OapiErrorHandler, opts ...runtime.RequestValidatorOption) (func(http.Handler) http.Handler, error)")
+
+		_, err = format.Source([]byte(code))
+		require.NoError(t, err, "Generated code should compile without syntax errors")
+	})
+
+	t.Run("disabled by default", func(t *testing.T) {
+		codes, err := Generate([]byte(readTestdata(t, "embedded-spec.yml")), Configuration{
+			PackageName: "api",
+			Generate: &GenerateOptions{
+				Handler: &HandlerOptions{
+					SpecPath: "/openapi.json",
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		code := codes.GetCombined()
+		assert.NotContains(t, code, "GetOpenAPISpec")
+		assert.NotContains(t, code, "/openapi.json")
+	})
+}
+
+func TestMultipartEncoding(t *testing.T) {
+	cfg := Configuration{
+		PackageName: "api",
+		Output: &Output{
+			UseSingleFile: true,
+		},
+		Generate: &GenerateOptions{
+			Client: true,
+		},
+	}
+
+	t.Run("parse context", func(t *testing.T) {
+		ctx, errs := CreateParseContext([]byte(readTestdata(t, "multipart-encoding.yml")), cfg)
+		require.Nil(t, errs)
+		require.Len(t, ctx.Operations, 1)
+
+		body := ctx.Operations[0].Body
+		require.NotNil(t, body)
+		assert.Equal(t, "multipart/form-data", body.ContentType)
+		assert.Equal(t, RequestBodyEncoding{
+			ContentType: "image/png",
+			Headers:     map[string]string{"X-Checksum": "none"},
+		}, body.Encoding["file"])
+	})
+
+	t.Run("generated code", func(t *testing.T) {
+		codes, err := Generate([]byte(readTestdata(t, "multipart-encoding.yml")), cfg)
+		require.NoError(t, err)
+
+		code := codes.GetCombined()
+		assert.Contains(t, code, `ContentType: "image/png"`)
+		assert.Contains(t, code, `"X-Checksum": "none"`)
+		assert.Contains(t, code, "File        runtime.File")
+
+		_, err = format.Source([]byte(code))
+		require.NoError(t, err, "Generated code should compile without syntax errors")
+	})
+}
+
+func TestStreamingResponses(t *testing.T) {
+	cfg := Configuration{
+		PackageName: "api",
+		Output: &Output{
+			UseSingleFile: true,
+		},
+		Generate: &GenerateOptions{
+			Client: true,
+		},
+	}
+
+	t.Run("parse context", func(t *testing.T) {
+		ctx, errs := CreateParseContext([]byte(readTestdata(t, "streaming.yml")), cfg)
+		require.Nil(t, errs)
+
+		streaming := map[string]bool{}
+		for _, op := range ctx.Operations {
+			streaming[op.ID] = op.Streaming
+		}
+		assert.Equal(t, map[string]bool{
+			"DownloadFile":  true,
+			"ExportRecords": true,
+			"GetArchive":    false,
+			"ListRecords":   false,
+		}, streaming)
+	})
+
+	t.Run("generated code", func(t *testing.T) {
+		codes, err := Generate([]byte(readTestdata(t, "streaming.yml")), cfg)
+		require.NoError(t, err)
+
+		code := codes.GetCombined()
+		assert.Contains(t, code, "DownloadFile(ctx context.Context, options *DownloadFileRequestOptions, reqEditors ...runtime.RequestEditorFn) (*runtime.StreamResponse, error)")
+		assert.Contains(t, code, "ExportRecords(ctx context.Context, reqEditors ...runtime.RequestEditorFn) (*runtime.StreamResponse, error)")
+		assert.Contains(t, code, `streamClient.ExecuteRequestStream(ctx, req, "/files/{id}")`)
+		assert.Contains(t, code, "target := new(DownloadFileErrorResponse)")
+		assert.Contains(t, code, `c.apiClient.ExecuteRequest(ctx, req, "/archives/{id}")`)
+		assert.Contains(t, code, `c.apiClient.ExecuteRequest(ctx, req, "/records")`)
+		assert.Contains(t, code, `OperationID: "ListRecords",`)
+
+		_, err = format.Source([]byte(code))
+		require.NoError(t, err, "Generated code should compile without syntax errors")
+	})
+
+	t.Run("invalid extension", func(t *testing.T) {
+		spec := strings.Replace(readTestdata(t, "streaming.yml"), "x-go-stream: true", "x-go-stream: [true]", 1)
+		_, err := Generate([]byte(spec), cfg)
+		require.ErrorContains(t, err, "x-go-stream")
+	})
+}
+
+func TestObservability(t *testing.T) {
+	newConfig := func(obs *ObservabilityOptions) Configuration {
+		return Configuration{
+			PackageName: "api",
+			Output: &Output{
+				UseSingleFile: true,
+			},
+			Generate: &GenerateOptions{
+				Client: true,
+				Handler: &HandlerOptions{
+					Kind: HandlerKindChi,
+				},
+				Observability: obs,
+			},
+		}
+	}
+
+	t.Run("tracing and metrics", func(t *testing.T) {
+		codes, err := Generate([]byte(readTestdata(t, "webhooks-callbacks.yml")), newConfig(&ObservabilityOptions{Tracing: true, Metrics: true}))
+		require.NoError(t, err)
+
+		code := codes.GetCombined()
+		// client
+		assert.Contains(t, code, "func NewObservabilityClientMiddleware(opts ...ObservabilityOption) runtime.ClientMiddleware")
+		assert.Contains(t, code, "opts = append([]runtime.APIClientOption{runtime.WithClientMiddleware(NewObservabilityClientMiddleware())}, opts...)")
+		assert.Contains(t, code, "trace.WithSpanKind(trace.SpanKindClient)")
+		assert.Contains(t, code, "cfg.propagator.Inject(ctx, propagation.HeaderCarrier(req.Header))")
+		assert.Contains(t, code, `"http.client.request.duration"`)
+
+		// handler
+		assert.Contains(t, code, `w, r, endObservation := a.observability.start(w, r, "CreateSubscription", "/subscriptions")`)
+		assert.Contains(t, code, `w, r, endObservation := a.observability.start(w, r, "PetCreated", "pet.created")`)
+		assert.Contains(t, code, "observability: newOapiServerObservability(nil)")
+		assert.Contains(t, code, "o.propagator.Extract(ctx, propagation.HeaderCarrier(r.Header))")
+		assert.Contains(t, code, `"http.server.request.duration"`)
+		assert.Contains(t, code, "func WithHTTPAdapterObservability(opts ...ObservabilityOption) HTTPAdapterOption")
+		assert.Contains(t, code, "func WithWebhookObservability(opts ...ObservabilityOption) WebhookHTTPAdapterOption")
+		assert.Contains(t, code, `"go.opentelemetry.io/otel/trace"`)
+
+		_, err = format.Source([]byte(code))
+		require.NoError(t, err, "Generated code should compile without syntax errors")
+	})
+
+	t.Run("metrics only", func(t *testing.T) {
+		codes, err := Generate([]byte(readTestdata(t, "webhooks-callbacks.yml")), newConfig(&ObservabilityOptions{Metrics: true}))
+		require.NoError(t, err)
+
+		code := codes.GetCombined()
+		assert.Contains(t, code, "func WithMeterProvider(mp metric.MeterProvider) ObservabilityOption")
+		assert.Contains(t, code, `"http.server.request.duration"`)
+		assert.NotContains(t, code, "WithTracerProvider")
+		assert.NotContains(t, code, `"go.opentelemetry.io/otel/trace"`)
+
+		_, err = format.Source([]byte(code))
+		require.NoError(t, err, "Generated code should compile without syntax errors")
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		for _, obs := range []*ObservabilityOptions{nil, {}} {
+			codes, err := Generate([]byte(readTestdata(t, "webhooks-callbacks.yml")), newConfig(obs))
+			require.NoError(t, err)
+
+			code := codes.GetCombined()
+			assert.NotContains(t, code, "go.opentelemetry.io")
+			assert.NotContains(t, code, "endObservation")
+			assert.NotContains(t, code, "ObservabilityOption")
+		}
+	})
+}
+
+func TestServerSentEvents(t *testing.T) {
+	cfg := Configuration{
+		PackageName: "api",
+		Output: &Output{
+			UseSingleFile: true,
+		},
+		Generate: &GenerateOptions{
+			Client: true,
+			Handler: &HandlerOptions{
+				Kind: HandlerKindChi,
+			},
+		},
+	}
+
+	t.Run("parse context", func(t *testing.T) {
+		ctx, errs := CreateParseContext([]byte(readTestdata(t, "server-sent-events.yml")), cfg)
+		require.Nil(t, errs)
+
+		responses := map[string]string{}
+		for _, op := range ctx.Operations {
+			assert.True(t, op.IsEventStream(), op.ID)
+			assert.True(t, op.Streaming, op.ID)
+			responses[op.ID] = op.Response.Success.ResponseName
+		}
+		assert.Equal(t, map[string]string{
+			"StreamNotifications": "StreamNotificationsResponse",
+			"StreamCompletion":    "StreamCompletionResponse",
+			"TailLogs":            "TailLogsResponse",
+		}, responses)
+	})
+
+	t.Run("generated code", func(t *testing.T) {
+		codes, err := Generate([]byte(readTestdata(t, "server-sent-events.yml")), cfg)
+		require.NoError(t, err)
+
+		code := codes.GetCombined()
+		// client
+		assert.Contains(t, code, "StreamNotificationsStream(ctx context.Context, options *StreamNotificationsRequestOptions, reqEditors ...runtime.RequestEditorFn) (*runtime.EventStream[StreamNotificationsResponse], error)")
+		assert.Contains(t, code, "return runtime.NewEventStream[StreamNotificationsResponse](resp), nil")
+		assert.Contains(t, code, `streamClient.ExecuteRequestStream(ctx, req, "/notifications")`)
+		assert.Contains(t, code, "type TailLogsResponse = string")
+		assert.Contains(t, code, "Token string `json:\"token\"")
+
+		// handler
+		assert.Contains(t, code, "StreamNotifications(ctx context.Context, opts *StreamNotificationsServiceRequestOptions, events *runtime.EventSender[StreamNotificationsResponse]) error")
+		assert.Contains(t, code, "TailLogs(ctx context.Context, events *runtime.EventSender[TailLogsResponse]) error")
+		assert.Contains(t, code, "events := runtime.NewEventSender[StreamNotificationsResponse](w, 200)")
+		assert.NotContains(t, code, "StreamNotificationsResponseData")
+
+		_, err = format.Source([]byte(code))
+		require.NoError(t, err, "Generated code should compile without syntax errors")
+	})
+
+	t.Run("hertz flushes events", func(t *testing.T) {
+		hertzCfg := cfg
+		hertzCfg.Generate = &GenerateOptions{
+			Handler: &HandlerOptions{
+				Kind: HandlerKindHertz,
+			},
+		}
+		codes, err := Generate([]byte(readTestdata(t, "server-sent-events.yml")), hertzCfg)
+		require.NoError(t, err)
+
+		code := codes.GetCombined()
+		assert.Contains(t, code, "adaptor.HertzHandler(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {")
+
+		_, err = format.Source([]byte(code))
+		require.NoError(t, err, "Generated code should compile without syntax errors")
+	})
+}
+
+func TestResponseHeaders(t *testing.T) {
+	cfg := Configuration{
+		PackageName: "api",
+		Output: &Output{
+			UseSingleFile: true,
+		},
+		Generate: &GenerateOptions{
+			Client: true,
+		},
+	}
+
+	t.Run("parse context", func(t *testing.T) {
+		ctx, errs := CreateParseContext([]byte(readTestdata(t, "response-headers.yml")), cfg)
+		require.Nil(t, errs)
+
+		headers := map[string][]string{}
+		for _, op := range ctx.Operations {
+			typed := op.Response.Success.TypedHeaders
+			if typed == nil {
+				continue
+			}
+			for _, p := range typed.Properties {
+				headers[typed.Name] = append(headers[typed.Name], p.JsonFieldName)
+			}
+		}
+		assert.Equal(t, map[string][]string{
+			"ListPetsResponseHeaders": {
+				"X-RateLimit-Remaining", "X-RateLimit-Reset", "X-Next-Cursor", "X-Request-ID", "X-Sort-Order", "X-Tags",
+			},
+			"CreatePetResponseHeaders": {"Location", "ETag"},
+		}, headers)
+	})
+
+	t.Run("generated code", func(t *testing.T) {
+		codes, err := Generate([]byte(readTestdata(t, "response-headers.yml")), cfg)
+		require.NoError(t, err)
+
+		code := codes.GetCombined()
+		assert.Contains(t, code, "ListPetsWithHeaders(ctx context.Context, options *ListPetsRequestOptions, reqEditors ...runtime.RequestEditorFn) (*ListPetsResponse, *ListPetsResponseHeaders, error)")
+		assert.Contains(t, code, "CreatePetWithHeaders(ctx context.Context, options *CreatePetRequestOptions, reqEditors ...runtime.RequestEditorFn) (*struct{}, *CreatePetResponseHeaders, error)")
+		assert.NotContains(t, code, "GetPetWithHeaders")
+
+		assert.Regexp(t, `XRateLimitRemaining\s+int\s+`+"`json:\"X-RateLimit-Remaining\" validate:\"gte=0\"`", code)
+		assert.Regexp(t, `XTags\s+\[\]string\s+`+"`json:\"X-Tags,omitempty\"`", code)
+		assert.Regexp(t, `Location\s+string\s+`+"`json:\"Location\" validate:\"required\"`", code)
+		assert.Regexp(t, `ETag\s+\*string\s+`+"`json:\"ETag,omitempty\"`", code)
+		assert.NotContains(t, code, `json:"Content-Type`)
+
+		assert.Contains(t, code, "func ParseListPetsResponseHeaders(header http.Header) (*ListPetsResponseHeaders, error)")
+		assert.Contains(t, code, "runtime.ParseString[int](value)")
+		assert.Contains(t, code, `runtime.ParseString[time.Time](value, "date-time")`)
+		assert.Contains(t, code, `runtime.ParseString[uuid.UUID](value, "uuid")`)
+		assert.Contains(t, code, "v := ListPetsResponseHeadersXSortOrder(value)")
+		assert.Contains(t, code, "headers, err := ParseListPetsResponseHeaders(resp.Headers)")
+
+		_, err = format.Source([]byte(code))
+		require.NoError(t, err, "Generated code should compile without syntax errors")
+	})
+
+	t.Run("handler", func(t *testing.T) {
+		handlerCfg := cfg
+		handlerCfg.Generate = &GenerateOptions{
+			Handler: &HandlerOptions{
+				Kind:       HandlerKindChi,
+				Validation: HandlerValidation{Response: true},
+			},
+		}
+		codes, err := Generate([]byte(readTestdata(t, "response-headers.yml")), handlerCfg)
+		require.NoError(t, err)
+
+		code := codes.GetCombined()
+		assert.Regexp(t, `ResponseHeaders\s+CreatePetResponseHeaders`, code)
+		assert.Contains(t, code, "func (r *CreatePetResponseData) WithResponseHeaders(h CreatePetResponseHeaders) *CreatePetResponseData")
+		assert.Contains(t, code, "func (c CreatePetResponseHeaders) Validate() error")
+		assert.NotContains(t, code, "GetPetResponseHeaders")
+
+		assert.Contains(t, code, "func (h ListPetsResponseHeaders) HTTPHeader() http.Header")
+		assert.Contains(t, code, `header.Set("X-RateLimit-Remaining", runtime.FormatString(h.XRateLimitRemaining))`)
+		assert.Contains(t, code, `header.Set("X-Request-ID", runtime.FormatString(*h.XRequestID))`)
+		assert.Contains(t, code, `header.Set("X-Tags", strings.Join(runtime.FormatStringSlice(h.XTags), ","))`)
+		assert.Contains(t, code, `header.Set("Location", h.Location)`)
+
+		assert.Contains(t, code, "if v, ok := any(resp.ResponseHeaders).(runtime.Validator); ok {")
+		assert.Contains(t, code, "response headers validation failed: %v")
+		assert.Contains(t, code, "for k, v := range resp.ResponseHeaders.HTTPHeader() {")
+
+		_, err = format.Source([]byte(code))
+		require.NoError(t, err, "Generated code should compile without syntax errors")
+	})
+}
+
+func TestStrictHandler(t *testing.T) {
+	cfg := Configuration{
+		PackageName: "api",
+		Output: &Output{
+			UseSingleFile: true,
+		},
+		Generate: &GenerateOptions{
+			Handler: &HandlerOptions{
+				Kind:       HandlerKindChi,
+				Strict:     true,
+				Validation: HandlerValidation{Response: true},
+			},
+		},
+	}
+
+	t.Run("parse context", func(t *testing.T) {
+		ctx, errs := CreateParseContext([]byte(readTestdata(t, "strict-server.yml")), cfg)
+		require.Nil(t, errs)
+
+		suffixes := map[string][]string{}
+		for _, op := range ctx.Operations {
+			for _, r := range op.Response.StrictResponses() {
+				suffixes[op.ID] = append(suffixes[op.ID], r.Suffix)
+			}
+		}
+		assert.Equal(t, map[string][]string{
+			"CreateJob": {"200JSON", "202JSON", "409JSON", "DefaultJSON"},
+			"DeleteJob": {"204", "404"},
+			"GetJobLog": {"200Text", "404JSON"},
+		}, suffixes)
+	})
+
+	t.Run("generated code", func(t *testing.T) {
+		codes, err := Generate([]byte(readTestdata(t, "strict-server.yml")), cfg)
+		require.NoError(t, err)
+
+		code := codes.GetCombined()
+		assert.Contains(t, code, "CreateJob(ctx context.Context, opts *CreateJobServiceRequestOptions) (CreateJobResponseObject, error)")
+		assert.Contains(t, code, "writeCreateJobResponse(w http.ResponseWriter) error")
+		assert.NotContains(t, code, "CreateJobResponseData")
+
+		assert.Regexp(t, `type CreateJob202JSON struct {\s+Body\s+CreateJobResponseJSON\s+Headers\s+http.Header\s+// .*\s+ResponseHeaders\s+CreateJobResponseHeaders\s+}`, code)
+		assert.Regexp(t, `type CreateJobDefaultJSON struct {\s+Body\s+Error\s+Headers\s+http.Header\s+StatusCode\s+int`, code)
+		assert.Regexp(t, `type DeleteJob404 struct {\s+Headers\s+http.Header\s+}`, code)
+		assert.Contains(t, code, "w.WriteHeader(409)")
+		assert.Contains(t, code, `w.Header().Set("Content-Type", "text/plain")`)
+		assert.Contains(t, code, "func (r CreateJob202JSON) Validate() error")
+		assert.NotContains(t, code, "func (r DeleteJob404) Validate() error")
+
+		assert.Contains(t, code, "if v, ok := resp.(runtime.Validator); ok {")
+		assert.Contains(t, code, "if err := resp.writeCreateJobResponse(w); err != nil {")
+
+		_, err = format.Source([]byte(code))
+		require.NoError(t, err, "Generated code should compile without syntax errors")
+	})
+}
+
+func TestNamingOptions(t *testing.T) {
+	cfg := Configuration{
+		PackageName: "api",
+		Output: &Output{
+			UseSingleFile: true,
+		},
+		Generate: &GenerateOptions{
+			Client: true,
+		},
+		Naming: &NamingOptions{
+			Initialisms: InitialismOptions{
+				Add:    []string{"SKU", "IBAN"},
+				Remove: []string{"TS"},
+			},
+			TypeSuffix: "Model",
+			Hooks: &NamingHooks{
+				OperationID: func(method, path, operationID, generated string) string {
+					if operationID == "" {
+						return "Fetch" + strings.TrimPrefix(generated, "Get")
+					}
+					return generated
+				},
+			},
+		},
+	}
+
+	codes, err := Generate([]byte(readTestdata(t, "naming.yml")), cfg)
+	require.NoError(t, err)
+
+	code := codes.GetCombined()
+	assert.Contains(t, code, "type ProductSKUModel struct")
+	assert.Contains(t, code, "type IBANAccountModel struct")
+	assert.Contains(t, code, "SKUID ")
+	assert.Contains(t, code, "CreatedTs ")
+	assert.Contains(t, code, "func (c *Client) FetchProductsProductSKU(")
+	assert.Contains(t, code, "func (c *Client) CreateIBANAccount(")
+	assert.Contains(t, code, "type FetchProductsProductSKUResponse = ProductSKUModel")
+
+	_, err = format.Source([]byte(code))
+	require.NoError(t, err, "Generated code should compile without syntax errors")
+}
+
+func TestNamingOptionsConcurrent(t *testing.T) {
+	spec := []byte(readTestdata(t, "naming.yml"))
+	newConfig := func(naming *NamingOptions) Configuration {
+		return Configuration{
+			PackageName: "api",
+			Output:      &Output{UseSingleFile: true},
+			Naming:      naming,
+		}
+	}
+
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if i%2 == 0 {
+				codes, err := Generate(spec, newConfig(&NamingOptions{
+					Initialisms: InitialismOptions{Add: []string{"SKU"}},
+				}))
+				assert.NoError(t, err)
+				assert.Contains(t, codes.GetCombined(), "type ProductSKU struct")
+				return
+			}
+			codes, err := Generate(spec, newConfig(nil))
+			assert.NoError(t, err)
+			assert.Contains(t, codes.GetCombined(), "type ProductSku struct")
+		}()
+	}
+	wg.Wait()
+}
+
+func TestXMLBodies(t *testing.T) {
+	cfg := Configuration{
+		PackageName: "api",
+		Output: &Output{
+			UseSingleFile: true,
+		},
+		Generate: &GenerateOptions{
+			Client: true,
+			Handler: &HandlerOptions{
+				Kind: HandlerKindChi,
+			},
+		},
+	}
+
+	codes, err := Generate([]byte(readTestdata(t, "xml-bodies.yml")), cfg)
+	require.NoError(t, err)
+
+	code := codes.GetCombined()
+
+	// xml object of the schema and its properties
+	assert.Regexp(t, `XMLName\s+xml.Name\s+`+"`json:\"-\" xml:\"https://example.com/schema/books book\"`", code)
+	assert.Contains(t, code, "`json:\"id\" validate:\"required\" xml:\"id,attr\"`")
+	assert.Contains(t, code, "`json:\"tags,omitempty\" xml:\"tags>tag,omitempty\"`")
+	assert.Contains(t, code, "`json:\"chapters,omitempty\" xml:\"chapter,omitempty\"`")
+	assert.Contains(t, code, "`json:\"name,omitempty\" xml:\"full-name,omitempty\"`")
+	assert.Regexp(t, `XMLName\s+xml.Name\s+`+"`json:\"-\" xml:\"problem\"`", code)
+
+	// JSON is preferred when a response declares both
+	assert.Regexp(t, `type Author struct {\s+Name \*string `+"`json:\"name,omitempty\"`", code)
+
+	// client and handler encoding
+	assert.Contains(t, code, "if err = xml.Unmarshal(bodyBytes, target); err != nil {")
+	assert.Contains(t, code, "err = xml.Unmarshal(bodyBytes, target)")
+	assert.Contains(t, code, "if err := xml.NewDecoder(r.Body).Decode(&body); err != nil {")
+	assert.Contains(t, code, "_ = xml.NewEncoder(w).Encode(resp.Body)")
+
+	_, err = format.Source([]byte(code))
+	require.NoError(t, err, "Generated code should compile without syntax errors")
+}
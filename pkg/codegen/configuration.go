@@ -24,6 +24,11 @@ import (
 // Filter is the configuration for filtering the paths and operations to be parsed.
 //
 // AdditionalImports defines any additional Go imports to add to the generated code.
+// ImportMapping maps external spec files or $ref prefixes to the Go packages their schemas are generated in.
+//
+//	The value is the import path, optionally preceded by the package name and a space.
+//	Schemas referenced through a mapped $ref are used from that package instead of being generated.
+//
 // ErrorMapping is the configuration for mapping the OpenAPI error responses to Go types.
 //
 //	The key is the spec error type name
@@ -40,8 +45,10 @@ type Configuration struct {
 	Generate *GenerateOptions `yaml:"generate"`
 	Filter   FilterConfig     `yaml:"filter,omitempty"`
 	Overlay  *OverlayOptions  `yaml:"overlay,omitempty"`
+	Naming   *NamingOptions   `yaml:"naming,omitempty"`
 
 	AdditionalImports []AdditionalImport `yaml:"additional-imports,omitempty"`
+	ImportMapping     map[string]string  `yaml:"import-mapping,omitempty"`
 	ErrorMapping      map[string]string  `yaml:"error-mapping,omitempty"`
 	Client            *Client            `yaml:"client,omitempty"`
 
@@ -145,6 +152,12 @@ func (o Configuration) OverwriteWith(other Configuration) Configuration {
 			if other.Output.UseSingleFile {
 				o.Output.UseSingleFile = other.Output.UseSingleFile
 			}
+			if other.Output.SplitBy != "" {
+				o.Output.SplitBy = other.Output.SplitBy
+			}
+			if other.Output.ImportPath != "" {
+				o.Output.ImportPath = other.Output.ImportPath
+			}
 		}
 	}
 
@@ -165,6 +178,12 @@ func (o Configuration) OverwriteWith(other Configuration) Configuration {
 			if other.Generate.AlwaysPrefixEnumValues {
 				o.Generate.AlwaysPrefixEnumValues = other.Generate.AlwaysPrefixEnumValues
 			}
+			if other.Generate.EmbeddedSpec {
+				o.Generate.EmbeddedSpec = other.Generate.EmbeddedSpec
+			}
+			if other.Generate.Observability != nil {
+				o.Generate.Observability = other.Generate.Observability
+			}
 			// Overwrite Validation options
 			if other.Generate.Validation.Skip {
 				o.Generate.Validation.Skip = other.Generate.Validation.Skip
@@ -193,6 +212,12 @@ func (o Configuration) OverwriteWith(other Configuration) Configuration {
 					if other.Generate.Handler.Validation.Response {
 						o.Generate.Handler.Validation.Response = other.Generate.Handler.Validation.Response
 					}
+					if other.Generate.Handler.Strict {
+						o.Generate.Handler.Strict = other.Generate.Handler.Strict
+					}
+					if other.Generate.Handler.SpecPath != "" {
+						o.Generate.Handler.SpecPath = other.Generate.Handler.SpecPath
+					}
 				}
 			}
 		}
@@ -209,19 +234,32 @@ func (o Configuration) OverwriteWith(other Configuration) Configuration {
 			if other.Client.Timeout != 0 {
 				o.Client.Timeout = other.Client.Timeout
 			}
+			if other.Client.ResponseEnvelope {
+				o.Client.ResponseEnvelope = other.Client.ResponseEnvelope
+			}
 		}
 	}
 
 	// Overwrite Filter
-	if !other.Filter.IsEmpty() {
+	if !other.Filter.IsEmpty() || other.Filter.Report != "" {
 		o.Filter = other.Filter
 	}
 
+	// Overwrite Naming
+	if other.Naming != nil {
+		o.Naming = other.Naming
+	}
+
 	// Overwrite AdditionalImports
 	if len(other.AdditionalImports) > 0 {
 		o.AdditionalImports = other.AdditionalImports
 	}
 
+	// Overwrite ImportMapping
+	if len(other.ImportMapping) > 0 {
+		o.ImportMapping = other.ImportMapping
+	}
+
 	// Overwrite ErrorMapping
 	if len(other.ErrorMapping) > 0 {
 		o.ErrorMapping = other.ErrorMapping
@@ -249,18 +287,35 @@ type AdditionalImport struct {
 type FilterConfig struct {
 	Include FilterParamsConfig `yaml:"include"`
 	Exclude FilterParamsConfig `yaml:"exclude"`
+
+	// Deprecated set to "exclude" removes deprecated operations. Defaults to "include".
+	Deprecated FilterDeprecated `yaml:"deprecated,omitempty"`
+
+	// Report is the file the CLI writes the filter report to, relative to the working directory,
+	// listing the operations, schema properties, extensions and schemas removed from the spec.
+	Report string `yaml:"report,omitempty"`
 }
 
 // IsEmpty returns true if the filter is empty.
 func (o FilterConfig) IsEmpty() bool {
-	return o.Include.IsEmpty() && o.Exclude.IsEmpty()
+	return o.Include.IsEmpty() && o.Exclude.IsEmpty() && o.Deprecated != FilterDeprecatedExclude
 }
 
+// FilterDeprecated specifies whether deprecated operations are kept by the filter.
+type FilterDeprecated string
+
+const (
+	FilterDeprecatedInclude FilterDeprecated = "include"
+	FilterDeprecatedExclude FilterDeprecated = "exclude"
+)
+
 // FilterParamsConfig is the configuration for filtering the paths to be parsed.
+// Paths, tags and operation IDs are exact values, globs or regular expressions prefixed with ~.
 type FilterParamsConfig struct {
 	Paths            []string            `yaml:"paths"`
 	Tags             []string            `yaml:"tags"`
 	OperationIDs     []string            `yaml:"operation-ids"`
+	Methods          []string            `yaml:"methods"`
 	SchemaProperties map[string][]string `yaml:"schema-properties"`
 	Extensions       []string            `yaml:"extensions"`
 }
@@ -270,10 +325,57 @@ func (o FilterParamsConfig) IsEmpty() bool {
 	return len(o.Paths) == 0 &&
 		len(o.Tags) == 0 &&
 		len(o.OperationIDs) == 0 &&
+		len(o.Methods) == 0 &&
 		len(o.SchemaProperties) == 0 &&
 		len(o.Extensions) == 0
 }
 
+// NamingOptions configures how Go names are generated from the names of the spec.
+type NamingOptions struct {
+	// Normalizer is the strategy converting names to Go names. Defaults to "initialisms".
+	Normalizer NameNormalizerKind `yaml:"normalizer,omitempty"`
+
+	// Initialisms adds or removes initialisms written in upper case by the "initialisms" normalizer.
+	Initialisms InitialismOptions `yaml:"initialisms,omitempty"`
+
+	// TypePrefix and TypeSuffix are added to the type names of component schemas, unless set with x-go-name.
+	TypePrefix string `yaml:"type-prefix,omitempty"`
+	TypeSuffix string `yaml:"type-suffix,omitempty"`
+
+	// Hooks overrides the generated names, they can only be set from Go.
+	Hooks *NamingHooks `yaml:"-"`
+}
+
+// NameNormalizerKind specifies how names are converted to Go names.
+type NameNormalizerKind string
+
+const (
+	// NameNormalizerInitialisms converts to CamelCase with initialisms in upper case: http_id -> HTTPID.
+	NameNormalizerInitialisms NameNormalizerKind = "initialisms"
+
+	// NameNormalizerLegacy converts to CamelCase as oapi-codegen v2 did by default, keeping the case of the words: http_id -> HttpId.
+	NameNormalizerLegacy NameNormalizerKind = "legacy"
+
+	// NameNormalizerCamel converts to CamelCase with every word capitalized, initialisms included: HTTPServerID -> HttpServerId.
+	NameNormalizerCamel NameNormalizerKind = "camel"
+)
+
+// InitialismOptions adds or removes initialisms from the default list.
+type InitialismOptions struct {
+	Add    []string `yaml:"add,omitempty"`
+	Remove []string `yaml:"remove,omitempty"`
+}
+
+// NamingHooks overrides the generated names.
+// Each hook receives the name generated by the configured normalizer and returns the name to use.
+type NamingHooks struct {
+	// TypeName returns the Go name for a schema, parameter, response or enum value name of the spec.
+	TypeName func(name, generated string) string
+
+	// OperationID returns the Go name of an operation. operationID is empty when the spec doesn't set it.
+	OperationID func(method, path, operationID, generated string) string
+}
+
 type GenerateOptions struct {
 	// Client specifies whether to generate a client. Defaults to false.
 	Client bool `yaml:"client"`
@@ -307,6 +409,30 @@ type GenerateOptions struct {
 	// Key is the Go struct tag name, value is the OpenAPI schema field to extract.
 	// Example: {"jsonschema": "description", "validate": "x-validation"}
 	AutoExtraTags map[string]string `yaml:"auto-extra-tags,omitempty"`
+
+	// EmbeddedSpec specifies whether to embed the processed spec (after overlays, filtering and pruning)
+	// in the generated code, compressed, exposed via GetOpenAPISpec(). Defaults to false.
+	EmbeddedSpec bool `yaml:"embedded-spec"`
+
+	// Observability specifies options for OpenTelemetry instrumentation of the generated client and handler.
+	// If nil, no instrumentation is generated.
+	Observability *ObservabilityOptions `yaml:"observability,omitempty"`
+}
+
+// ObservabilityOptions specifies the OpenTelemetry instrumentation of the generated client and handler.
+type ObservabilityOptions struct {
+	// Tracing creates client and server spans named after the operation ID,
+	// propagating the trace context through request headers. Defaults to false.
+	Tracing bool `yaml:"tracing"`
+
+	// Metrics records the duration of client and server requests, labelled by operation ID and status code.
+	// Defaults to false.
+	Metrics bool `yaml:"metrics"`
+}
+
+// Enabled returns true if any instrumentation is generated.
+func (o *ObservabilityOptions) Enabled() bool {
+	return o != nil && (o.Tracing || o.Metrics)
 }
 
 type ValidationOptions struct {
@@ -326,8 +452,24 @@ type Output struct {
 	UseSingleFile bool   `yaml:"use-single-file"`
 	Directory     string `yaml:"directory"`
 	Filename      string `yaml:"filename"`
+
+	// SplitBy generates the operations in sub-packages of the generated package, grouped by tag.
+	// The types stay in the generated package, imported by the sub-packages.
+	SplitBy OutputSplitKind `yaml:"split-by,omitempty"`
+
+	// ImportPath is the full import path of the generated package.
+	// Required by SplitBy, as the sub-packages import the types from it.
+	ImportPath string `yaml:"import-path,omitempty"`
 }
 
+// OutputSplitKind specifies how the generated operations are split into packages.
+type OutputSplitKind string
+
+const (
+	// OutputSplitByTag generates a sub-package per operation tag.
+	OutputSplitByTag OutputSplitKind = "tag"
+)
+
 // OverlayOptions specifies OpenAPI Overlay files to apply to the spec before generation.
 // See https://spec.openapis.org/overlay/v1.0.0.html for the Overlay specification.
 type OverlayOptions struct {
@@ -339,6 +481,11 @@ type OverlayOptions struct {
 type Client struct {
 	Name    string        `yaml:"name"`
 	Timeout time.Duration `yaml:"timeout"`
+
+	// ResponseEnvelope generates an additional <Operation>WithResponse method per operation.
+	// It returns an envelope with the decoded body of every declared response status
+	// along with the raw response, instead of failing on anything but the success status.
+	ResponseEnvelope bool `yaml:"response-envelope"`
 }
 
 // HandlerKind specifies the router/framework to generate handler code for.
@@ -382,6 +529,15 @@ type HandlerOptions struct {
 	// Validation specifies options for request/response validation in handlers.
 	Validation HandlerValidation `yaml:"validation"`
 
+	// Strict makes service methods return a <Operation>ResponseObject, implemented by a type per declared response,
+	// e.g. <Operation>202JSON or <Operation>404, instead of <Operation>ResponseData. Defaults to false.
+	Strict bool `yaml:"strict"`
+
+	// RequireSecurityValidator makes the adapter reject requests to operations with security requirements
+	// with 401 Unauthorized when no SecurityValidator is set. Defaults to false: without a validator,
+	// these requests are served without checking them.
+	RequireSecurityValidator bool `yaml:"require-security-validator"`
+
 	// ModelsPackageAlias is the package alias to prefix model types with.
 	// Used when models are generated separately (generate.models: false).
 	// Example: "types" will generate "types.User" instead of "User".
@@ -391,6 +547,10 @@ type HandlerOptions struct {
 	// Defaults to 32MB (matching Go stdlib). Files exceeding this are stored in temp files.
 	MultipartMaxMemory int `yaml:"multipart-max-memory"`
 
+	// SpecPath is the path the generated router serves the embedded spec at, e.g. "/openapi.json".
+	// Requires generate.embedded-spec. If empty, the spec is not served.
+	SpecPath string `yaml:"spec-path"`
+
 	// Output specifies output for scaffolded handler files (service.go, middleware.go).
 	// Falls back to root output if nil.
 	Output *ScaffoldOutput `yaml:"output"`
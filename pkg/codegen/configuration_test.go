@@ -240,12 +240,14 @@ func TestConfiguration_OverwriteWith(t *testing.T) {
 		overrides := Configuration{
 			Generate: &GenerateOptions{
 				DefaultIntType: "int64",
+				EmbeddedSpec:   true,
 			},
 		}
 
 		result := userConfig.OverwriteWith(overrides)
 		assert.True(t, result.Generate.Client)                   // not overwritten
 		assert.Equal(t, "int64", result.Generate.DefaultIntType) // overwritten
+		assert.True(t, result.Generate.EmbeddedSpec)             // overwritten
 	})
 
 	t.Run("other Client fields overwrite user Client fields", func(t *testing.T) {
@@ -257,13 +259,15 @@ func TestConfiguration_OverwriteWith(t *testing.T) {
 		}
 		overrides := Configuration{
 			Client: &Client{
-				Timeout: 10 * time.Second,
+				Timeout:          10 * time.Second,
+				ResponseEnvelope: true,
 			},
 		}
 
 		result := userConfig.OverwriteWith(overrides)
 		assert.Equal(t, "UserClient", result.Client.Name)      // not overwritten
 		assert.Equal(t, 10*time.Second, result.Client.Timeout) // overwritten
+		assert.True(t, result.Client.ResponseEnvelope)         // overwritten
 	})
 
 	t.Run("other AdditionalImports overwrite user AdditionalImports", func(t *testing.T) {
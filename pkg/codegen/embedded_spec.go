@@ -0,0 +1,43 @@
+// Copyright 2025 DoorDash, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package codegen
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+)
+
+// embeddedSpecLineLength is the length of the string literals the encoded spec is split into.
+const embeddedSpecLineLength = 80
+
+// encodeEmbeddedSpec gzips the spec and returns it base64 encoded, in lines suitable for a string slice literal.
+func encodeEmbeddedSpec(spec []byte) ([]string, error) {
+	var buf bytes.Buffer
+	zw, err := gzip.NewWriterLevel(&buf, gzip.BestCompression)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = zw.Write(spec); err != nil {
+		return nil, err
+	}
+	if err = zw.Close(); err != nil {
+		return nil, err
+	}
+
+	encoded := base64.StdEncoding.EncodeToString(buf.Bytes())
+	res := make([]string, 0, len(encoded)/embeddedSpecLineLength+1)
+	for len(encoded) > embeddedSpecLineLength {
+		res = append(res, encoded[:embeddedSpecLineLength])
+		encoded = encoded[embeddedSpecLineLength:]
+	}
+	return append(res, encoded), nil
+}
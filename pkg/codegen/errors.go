@@ -34,4 +34,11 @@ var (
 	ErrHandlerKindRequired                       = errors.New("handler kind is required")
 	ErrHandlerKindUnsupported                    = errors.New("unsupported handler kind")
 	ErrServerHandlerPackageRequired              = errors.New("server handler-package is required when server generation is enabled")
+	ErrFilterDeprecatedUnsupported               = errors.New("unsupported filter deprecated value")
+	ErrNameNormalizerUnsupported                 = errors.New("unsupported name normalizer")
+	ErrOutputSplitByUnsupported                  = errors.New("unsupported output split-by value")
+	ErrOutputSplitImportPathRequired             = errors.New("output import-path is required when split-by is set")
+	ErrOutputSplitSingleFile                     = errors.New("output split-by is not supported with use-single-file")
+	ErrOutputSplitServer                         = errors.New("server generation is not supported with output split-by")
+	ErrImportMappingInvalid                      = errors.New("invalid import mapping")
 )
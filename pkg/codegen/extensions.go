@@ -54,6 +54,9 @@ const (
 
 	// extMCP configures MCP tool generation for an operation
 	extMCP = "x-mcp"
+
+	// extGoStream makes the client return the success response body of an operation as a stream
+	extGoStream = "x-go-stream"
 )
 
 // MCPExtension configures MCP tool generation for an operation.
@@ -12,6 +12,7 @@ package codegen
 
 import (
 	"fmt"
+	"regexp"
 	"slices"
 	"strings"
 
@@ -21,118 +22,266 @@ import (
 	"go.yaml.in/yaml/v4"
 )
 
-func filterOutDocument(doc libopenapi.Document, cfg FilterConfig) (*v3high.Document, bool, error) {
+// FilterReport lists what the filter removed from the spec.
+type FilterReport struct {
+	Operations       []FilteredOperation `yaml:"operations,omitempty"`
+	SchemaProperties map[string][]string `yaml:"schema-properties,omitempty"`
+	SchemaExtensions map[string][]string `yaml:"schema-extensions,omitempty"`
+
+	// Schemas are the component schemas pruned once nothing referenced them anymore.
+	Schemas []string `yaml:"schemas,omitempty"`
+}
+
+// IsEmpty returns true if nothing was filtered out.
+func (r FilterReport) IsEmpty() bool {
+	return len(r.Operations) == 0 && len(r.SchemaProperties) == 0 && len(r.SchemaExtensions) == 0
+}
+
+// FilteredOperation is an operation removed by the filter, along with the setting that removed it,
+// e.g. "exclude.tags" or "deprecated".
+type FilteredOperation struct {
+	Method      string `yaml:"method"`
+	Path        string `yaml:"path"`
+	OperationID string `yaml:"operation-id,omitempty"`
+	Reason      string `yaml:"reason"`
+}
+
+func filterOutDocument(doc libopenapi.Document, cfg FilterConfig) (*v3high.Document, FilterReport, error) {
+	var report FilterReport
+
 	model, err := doc.BuildV3Model()
 	if err != nil {
-		return nil, false, fmt.Errorf("error building model: %w", err)
+		return nil, report, fmt.Errorf("error building model: %w", err)
 	}
 
-	removedOperations := filterOperations(&model.Model, cfg)
-	removedProperties := filterComponentSchemaProperties(&model.Model, cfg)
-	filtered := removedOperations || removedProperties
+	report.Operations, err = filterOperations(&model.Model, cfg)
+	if err != nil {
+		return nil, report, err
+	}
+	report.SchemaProperties, report.SchemaExtensions = filterComponentSchemaProperties(&model.Model, cfg)
 
 	// Don't reload yet - let the caller decide when to reload (after pruning if needed)
-	return &model.Model, filtered, nil
+	return &model.Model, report, nil
 }
 
-func filterOperations(model *v3high.Document, cfg FilterConfig) bool {
-	if cfg.IsEmpty() {
-		return false
+// Marshal returns the report as YAML.
+func (r FilterReport) Marshal() ([]byte, error) {
+	contents, err := yaml.Marshal(r)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding filter report: %w", err)
 	}
+	return contents, nil
+}
 
-	removed := false
-	paths := map[string]*v3high.PathItem{}
+// filterPatterns matches values against the patterns of a filter.
+// A pattern is an exact value, a glob where * matches any characters but /, ** any characters
+// and ? a single character but /, or a regular expression prefixed with ~.
+type filterPatterns []*regexp.Regexp
 
-	// iterate over copy
-	if model.Paths != nil && model.Paths.PathItems != nil {
-		for path, pathItem := range model.Paths.PathItems.FromOldest() {
-			paths[path] = pathItem
+func newFilterPatterns(patterns []string) (filterPatterns, error) {
+	res := make(filterPatterns, 0, len(patterns))
+	for _, pattern := range patterns {
+		expr, isRegexp := strings.CutPrefix(pattern, "~")
+		if !isRegexp {
+			expr = globToRegexp(pattern)
 		}
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid filter pattern %q: %w", pattern, err)
+		}
+		res = append(res, re)
 	}
+	return res, nil
+}
 
-	for path, pathItem := range paths {
-		if len(cfg.Include.Paths) > 0 && !slices.Contains(cfg.Include.Paths, path) {
-			model.Paths.PathItems.Delete(path)
-			removed = true
-			continue
+func (p filterPatterns) match(value string) bool {
+	for _, re := range p {
+		if re.MatchString(value) {
+			return true
 		}
+	}
+	return false
+}
 
-		if len(cfg.Exclude.Paths) > 0 && slices.Contains(cfg.Exclude.Paths, path) {
-			model.Paths.PathItems.Delete(path)
-			removed = true
-			continue
+func (p filterPatterns) matchAny(values []string) bool {
+	return slices.ContainsFunc(values, p.match)
+}
+
+// globToRegexp converts a glob to an anchored regular expression.
+func globToRegexp(glob string) string {
+	var b strings.Builder
+	b.WriteString("^")
+	for i := 0; i < len(glob); i++ {
+		switch {
+		case strings.HasPrefix(glob[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case glob[i] == '*':
+			b.WriteString("[^/]*")
+		case glob[i] == '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(glob[i : i+1]))
 		}
+	}
+	b.WriteString("$")
+	return b.String()
+}
 
-		for method, op := range pathItem.GetOperations().FromOldest() {
-			remove := false
+// operationFilter holds the compiled patterns of the include or exclude part of a filter.
+type operationFilter struct {
+	paths        filterPatterns
+	tags         filterPatterns
+	operationIDs filterPatterns
+	methods      map[string]bool
+}
 
-			// Tags
-			for _, tag := range op.Tags {
-				if slices.Contains(cfg.Exclude.Tags, tag) {
-					remove = true
-					break
-				}
-			}
+func newOperationFilter(cfg FilterParamsConfig) (operationFilter, error) {
+	var (
+		res operationFilter
+		err error
+	)
+	if res.paths, err = newFilterPatterns(cfg.Paths); err != nil {
+		return res, err
+	}
+	if res.tags, err = newFilterPatterns(cfg.Tags); err != nil {
+		return res, err
+	}
+	if res.operationIDs, err = newFilterPatterns(cfg.OperationIDs); err != nil {
+		return res, err
+	}
+	res.methods = make(map[string]bool, len(cfg.Methods))
+	for _, method := range cfg.Methods {
+		res.methods[strings.ToLower(method)] = true
+	}
+	return res, nil
+}
 
-			if !remove && len(cfg.Include.Tags) > 0 {
-				// Only include if it matches Include.Tags
-				includeMatch := false
-				for _, tag := range op.Tags {
-					if slices.Contains(cfg.Include.Tags, tag) {
-						includeMatch = true
-						break
-					}
-				}
-				if !includeMatch {
-					remove = true
-				}
-			}
+// filterOperations removes the operations not selected by the filter and returns them.
+// Exclusions take precedence over inclusions.
+func filterOperations(model *v3high.Document, cfg FilterConfig) ([]FilteredOperation, error) {
+	switch cfg.Deprecated {
+	case "", FilterDeprecatedInclude, FilterDeprecatedExclude:
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrFilterDeprecatedUnsupported, cfg.Deprecated)
+	}
+
+	if cfg.IsEmpty() {
+		return nil, nil
+	}
+
+	include, err := newOperationFilter(cfg.Include)
+	if err != nil {
+		return nil, err
+	}
+	exclude, err := newOperationFilter(cfg.Exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	if model.Paths == nil || model.Paths.PathItems == nil {
+		return nil, nil
+	}
+
+	// iterate over copy
+	var paths []string
+	for path := range model.Paths.PathItems.KeysFromOldest() {
+		paths = append(paths, path)
+	}
+
+	var removed []FilteredOperation
+	for _, path := range paths {
+		pathItem := model.Paths.PathItems.GetOrZero(path)
+
+		pathReason := ""
+		switch {
+		case exclude.paths.match(path):
+			pathReason = "exclude.paths"
+		case len(include.paths) > 0 && !include.paths.match(path):
+			pathReason = "include.paths"
+		}
 
-			// OperationIDs
-			if len(cfg.Exclude.OperationIDs) > 0 && slices.Contains(cfg.Exclude.OperationIDs, op.OperationId) {
-				remove = true
+		for method, op := range pathItem.GetOperations().FromOldest() {
+			method = strings.ToLower(method)
+			reason := pathReason
+			if reason == "" {
+				reason = operationFilterReason(method, op, cfg.Deprecated, include, exclude)
 			}
-			if len(cfg.Include.OperationIDs) > 0 && !slices.Contains(cfg.Include.OperationIDs, op.OperationId) {
-				remove = true
+			if reason == "" {
+				continue
 			}
 
-			if remove {
-				removed = true
-				switch strings.ToLower(method) {
-				case "get":
-					pathItem.Get = nil
-				case "post":
-					pathItem.Post = nil
-				case "put":
-					pathItem.Put = nil
-				case "delete":
-					pathItem.Delete = nil
-				case "patch":
-					pathItem.Patch = nil
-				case "head":
-					pathItem.Head = nil
-				case "options":
-					pathItem.Options = nil
-				case "trace":
-					pathItem.Trace = nil
-				}
+			removed = append(removed, FilteredOperation{
+				Method:      strings.ToUpper(method),
+				Path:        path,
+				OperationID: op.OperationId,
+				Reason:      reason,
+			})
+
+			switch method {
+			case "get":
+				pathItem.Get = nil
+			case "post":
+				pathItem.Post = nil
+			case "put":
+				pathItem.Put = nil
+			case "delete":
+				pathItem.Delete = nil
+			case "patch":
+				pathItem.Patch = nil
+			case "head":
+				pathItem.Head = nil
+			case "options":
+				pathItem.Options = nil
+			case "trace":
+				pathItem.Trace = nil
 			}
 		}
+
+		if pathReason != "" {
+			model.Paths.PathItems.Delete(path)
+		}
 	}
 
-	return removed
+	return removed, nil
+}
+
+// operationFilterReason returns the filter setting removing the operation, or an empty string if it's kept.
+func operationFilterReason(method string, op *v3high.Operation, deprecated FilterDeprecated, include, exclude operationFilter) string {
+	switch {
+	case exclude.methods[method]:
+		return "exclude.methods"
+	case exclude.tags.matchAny(op.Tags):
+		return "exclude.tags"
+	case exclude.operationIDs.match(op.OperationId):
+		return "exclude.operation-ids"
+	case deprecated == FilterDeprecatedExclude && op.Deprecated != nil && *op.Deprecated:
+		return "deprecated"
+	case len(include.methods) > 0 && !include.methods[method]:
+		return "include.methods"
+	case len(include.tags) > 0 && !include.tags.matchAny(op.Tags):
+		return "include.tags"
+	case len(include.operationIDs) > 0 && !include.operationIDs.match(op.OperationId):
+		return "include.operation-ids"
+	}
+	return ""
 }
 
-func filterComponentSchemaProperties(model *v3high.Document, cfg FilterConfig) bool {
+// filterComponentSchemaProperties removes the filtered out properties and extensions of the component schemas,
+// returning them by schema name.
+func filterComponentSchemaProperties(model *v3high.Document, cfg FilterConfig) (map[string][]string, map[string][]string) {
 	if cfg.IsEmpty() {
-		return false
+		return nil, nil
 	}
 
 	if model.Components == nil || model.Components.Schemas == nil {
-		return false
+		return nil, nil
 	}
 
-	removed := false
+	var (
+		removedProperties = map[string][]string{}
+		removedExtensions = map[string][]string{}
+	)
 	includeExts := sliceToBoolMap(cfg.Include.Extensions)
 	excludeExts := sliceToBoolMap(cfg.Exclude.Extensions)
 
@@ -147,11 +296,10 @@ func filterComponentSchemaProperties(model *v3high.Document, cfg FilterConfig) b
 			for key, val := range schema.Extensions.FromOldest() {
 				if shouldIncludeExtension(key, includeExts, excludeExts) {
 					newExtensions.Set(key, val)
+				} else {
+					removedExtensions[schemaName] = append(removedExtensions[schemaName], key)
 				}
 			}
-			if newExtensions.Len() != schema.Extensions.Len() {
-				removed = true
-			}
 			schema.Extensions = newExtensions
 		}
 
@@ -166,23 +314,22 @@ func filterComponentSchemaProperties(model *v3high.Document, cfg FilterConfig) b
 				continue
 			}
 
-			if include := cfg.Include.SchemaProperties[schemaName]; include != nil {
-				if !slices.Contains(include, propName) {
-					schema.Properties.Delete(propName)
-					removed = true
-				}
-			}
-
-			if exclude := cfg.Exclude.SchemaProperties[schemaName]; exclude != nil {
-				if slices.Contains(exclude, propName) {
-					schema.Properties.Delete(propName)
-					removed = true
-				}
+			include := cfg.Include.SchemaProperties[schemaName]
+			exclude := cfg.Exclude.SchemaProperties[schemaName]
+			if (include != nil && !slices.Contains(include, propName)) || slices.Contains(exclude, propName) {
+				schema.Properties.Delete(propName)
+				removedProperties[schemaName] = append(removedProperties[schemaName], propName)
 			}
 		}
 	}
 
-	return removed
+	if len(removedProperties) == 0 {
+		removedProperties = nil
+	}
+	if len(removedExtensions) == 0 {
+		removedExtensions = nil
+	}
+	return removedProperties, removedExtensions
 }
 
 func shouldIncludeExtension(ext string, includeExts, excludeExts map[string]bool) bool {
@@ -344,3 +344,129 @@ func TestFilterOperationsByPath(t *testing.T) {
 		assert.Contains(t, combined, `"/enum"`)
 	})
 }
+
+func TestFilterPatterns(t *testing.T) {
+	tests := []struct {
+		pattern string
+		value   string
+		want    bool
+	}{
+		{"/cat", "/cat", true},
+		{"/cat", "/cats", false},
+		{"/users/{id}", "/users/{id}", true},
+		{"/v2/*", "/v2/users", true},
+		{"/v2/*", "/v2/users/{id}", false},
+		{"/v2/**", "/v2/users/{id}", true},
+		{"/v2/admin/**", "/v2/users", false},
+		{"/v?/users", "/v1/users", true},
+		{"get*", "getUser", true},
+		{"~^/v[12]/users$", "/v1/users", true},
+		{"~Users", "listUsersV1", true},
+		{"~^Users", "listUsersV1", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.value, func(t *testing.T) {
+			patterns, err := newFilterPatterns([]string{tt.pattern})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, patterns.match(tt.value))
+		})
+	}
+
+	t.Run("invalid regular expression", func(t *testing.T) {
+		_, err := newFilterPatterns([]string{"~^/v[12/"})
+		require.ErrorContains(t, err, `invalid filter pattern "~^/v[12/"`)
+	})
+}
+
+func TestFilterOperationsRemoved(t *testing.T) {
+	filter := func(t *testing.T, cfg FilterConfig) ([]FilteredOperation, error) {
+		doc, err := LoadDocumentFromContents([]byte(readTestdata(t, "filter-operations.yml")))
+		require.NoError(t, err)
+		model, err := doc.BuildV3Model()
+		require.NoError(t, err)
+		return filterOperations(&model.Model, cfg)
+	}
+
+	t.Run("glob paths", func(t *testing.T) {
+		removed, err := filter(t, FilterConfig{
+			Exclude: FilterParamsConfig{Paths: []string{"/v2/admin/**"}},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []FilteredOperation{
+			{Method: "GET", Path: "/v2/admin/settings/audit", OperationID: "getAuditSettings", Reason: "exclude.paths"},
+		}, removed)
+	})
+
+	t.Run("regular expression operation ids and tags", func(t *testing.T) {
+		removed, err := filter(t, FilterConfig{
+			Include: FilterParamsConfig{OperationIDs: []string{"~^(list|get)"}},
+			Exclude: FilterParamsConfig{Tags: []string{"~^admin-"}},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []FilteredOperation{
+			{Method: "POST", Path: "/v2/users", OperationID: "createUser", Reason: "include.operation-ids"},
+			{Method: "DELETE", Path: "/v2/users/{id}", OperationID: "deleteUser", Reason: "include.operation-ids"},
+			{Method: "GET", Path: "/v2/admin/settings/audit", OperationID: "getAuditSettings", Reason: "exclude.tags"},
+		}, removed)
+	})
+
+	t.Run("methods", func(t *testing.T) {
+		removed, err := filter(t, FilterConfig{
+			Include: FilterParamsConfig{Methods: []string{"GET", "post"}},
+			Exclude: FilterParamsConfig{Methods: []string{"post"}},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []FilteredOperation{
+			{Method: "POST", Path: "/v2/users", OperationID: "createUser", Reason: "exclude.methods"},
+			{Method: "DELETE", Path: "/v2/users/{id}", OperationID: "deleteUser", Reason: "include.methods"},
+		}, removed)
+	})
+
+	t.Run("deprecated", func(t *testing.T) {
+		removed, err := filter(t, FilterConfig{Deprecated: FilterDeprecatedExclude})
+		require.NoError(t, err)
+		assert.Equal(t, []FilteredOperation{
+			{Method: "GET", Path: "/v1/users", OperationID: "listUsersV1", Reason: "deprecated"},
+		}, removed)
+	})
+
+	t.Run("unsupported deprecated value", func(t *testing.T) {
+		_, err := filter(t, FilterConfig{Deprecated: "only"})
+		require.ErrorIs(t, err, ErrFilterDeprecatedUnsupported)
+	})
+}
+
+func TestFilterReport(t *testing.T) {
+	cfg := Configuration{
+		PackageName: "api",
+		Filter: FilterConfig{
+			Include: FilterParamsConfig{
+				Paths: []string{"/v*/users"},
+			},
+			Deprecated: FilterDeprecatedExclude,
+		},
+		Generate: &GenerateOptions{
+			Client: true,
+		},
+		Output: &Output{
+			UseSingleFile: true,
+		},
+	}
+
+	code, report, err := GenerateWithFilterReport([]byte(readTestdata(t, "filter-operations.yml")), cfg)
+	require.NoError(t, err)
+
+	combined := code.GetCombined()
+	assert.Contains(t, combined, "func (c *Client) ListUsers(")
+	assert.NotContains(t, combined, "ListUsersV1")
+	assert.NotContains(t, combined, "type LegacyUser struct")
+
+	assert.Equal(t, FilterReport{
+		Operations: []FilteredOperation{
+			{Method: "GET", Path: "/v1/users", OperationID: "listUsersV1", Reason: "deprecated"},
+			{Method: "DELETE", Path: "/v2/users/{id}", OperationID: "deleteUser", Reason: "include.paths"},
+			{Method: "GET", Path: "/v2/admin/settings/audit", OperationID: "getAuditSettings", Reason: "include.paths"},
+		},
+		Schemas: []string{"LegacyUser"},
+	}, report)
+}
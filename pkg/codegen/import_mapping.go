@@ -0,0 +1,323 @@
+// Copyright 2025 DoorDash, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package codegen
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"go.yaml.in/yaml/v4"
+)
+
+// importMapping is a parsed entry of the import-mapping configuration.
+type importMapping struct {
+	prefix string
+	goImport
+}
+
+// parseImportMapping parses the import-mapping configuration, longest prefixes first.
+func parseImportMapping(mapping map[string]string) ([]importMapping, error) {
+	res := make([]importMapping, 0, len(mapping))
+	for prefix, value := range mapping {
+		fields := strings.Fields(value)
+		var gi goImport
+		switch len(fields) {
+		case 1:
+			gi = goImport{Name: importPathPackageName(fields[0]), Path: fields[0]}
+			if fields[0] == importMappingCurrentPackage {
+				gi.Name = importMappingCurrentPackage
+			}
+		case 2:
+			gi = goImport{Name: fields[0], Path: fields[1]}
+		default:
+			return nil, fmt.Errorf("%w: %q: %q", ErrImportMappingInvalid, prefix, value)
+		}
+		if prefix == "" {
+			return nil, fmt.Errorf("%w: empty $ref prefix", ErrImportMappingInvalid)
+		}
+		res = append(res, importMapping{prefix: cleanRefPrefix(prefix), goImport: gi})
+	}
+
+	slices.SortFunc(res, func(a, b importMapping) int {
+		if len(a.prefix) != len(b.prefix) {
+			return len(b.prefix) - len(a.prefix)
+		}
+		return strings.Compare(a.prefix, b.prefix)
+	})
+	return res, nil
+}
+
+// cleanRefPrefix cleans the file path of a mapped $ref prefix the way the $refs are resolved,
+// keeping the trailing slash of directories.
+func cleanRefPrefix(prefix string) string {
+	if strings.Contains(prefix, "://") {
+		return prefix
+	}
+	res := path.Clean(prefix)
+	if strings.HasSuffix(prefix, "/") && res != "/" {
+		res += "/"
+	}
+	return res
+}
+
+// match returns true if the mapping applies to the $ref value.
+func (m importMapping) match(ref string) bool {
+	ref = strings.TrimPrefix(ref, "./")
+	rest, ok := strings.CutPrefix(ref, m.prefix)
+	if !ok {
+		return false
+	}
+	return rest == "" || strings.HasPrefix(rest, "#") || strings.HasPrefix(rest, "/") ||
+		strings.HasSuffix(m.prefix, "/") || strings.HasSuffix(m.prefix, "#")
+}
+
+// importMapper replaces the schema $refs matching the import mapping with x-go-type extensions,
+// so the referenced schemas are used from the mapped Go packages instead of being generated.
+// Mapped external files don't need to be available then.
+// Only references to a schema or a whole file are replaced, e.g. common.yaml#/components/schemas/Money.
+type importMapper struct {
+	mappings []importMapping
+	// naming generates the type names the way the mapped packages were
+	naming *namer
+}
+
+// newImportMapper returns the mapper of the configuration, nil if there's no import mapping.
+func newImportMapper(cfg Configuration) (*importMapper, error) {
+	if len(cfg.ImportMapping) == 0 {
+		return nil, nil
+	}
+
+	mappings, err := parseImportMapping(cfg.ImportMapping)
+	if err != nil {
+		return nil, err
+	}
+
+	naming, err := newNamer(cfg.Naming)
+	if err != nil {
+		return nil, err
+	}
+	return &importMapper{mappings: mappings, naming: naming}, nil
+}
+
+// applyImportMapping replaces the mapped $refs of the spec contents.
+func applyImportMapping(contents []byte, cfg Configuration) ([]byte, error) {
+	mapper, err := newImportMapper(cfg)
+	if mapper == nil || err != nil {
+		return contents, err
+	}
+	if res, _ := mapper.rewrite(contents, "."); res != nil {
+		return res, nil
+	}
+	return contents, nil
+}
+
+// rewrite replaces the mapped $refs of a spec file in dir, relative to the root spec directory,
+// which the mapped prefixes are relative to.
+// It returns the rewritten contents, nil if nothing was mapped,
+// and the local files referenced by the other $refs, relative to the root spec directory.
+func (m *importMapper) rewrite(contents []byte, dir string) ([]byte, []string) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(contents, &root); err != nil {
+		// Let the regular loading report the problem
+		return nil, nil
+	}
+
+	changed := false
+	var files []string
+	var visit func(node *yaml.Node)
+	visit = func(node *yaml.Node) {
+		if node.Kind == yaml.MappingNode {
+			file, ok := m.replaceRef(node, dir)
+			if ok {
+				changed = true
+				return
+			}
+			if file != "" {
+				files = append(files, file)
+			}
+		}
+		for _, child := range node.Content {
+			visit(child)
+		}
+	}
+	visit(&root)
+
+	if !changed {
+		return nil, files
+	}
+	res, err := yaml.Marshal(&root)
+	if err != nil {
+		return nil, files
+	}
+	return res, files
+}
+
+// replaceRef replaces the $ref of the mapping node with x-go-type and x-go-type-import extensions,
+// if it matches one of the mappings. Other keys, like description, are kept.
+// If the $ref is not mapped, the local file it references is returned.
+func (m *importMapper) replaceRef(node *yaml.Node, dir string) (string, bool) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value != "$ref" || node.Content[i+1].Kind != yaml.ScalarNode {
+			continue
+		}
+		ref, file := resolveRef(node.Content[i+1].Value, dir)
+
+		idx := slices.IndexFunc(m.mappings, func(mapping importMapping) bool {
+			return mapping.match(ref)
+		})
+		if idx < 0 || m.mappings[idx].Path == importMappingCurrentPackage {
+			return file, false
+		}
+		typeName, ok := m.naming.mappedRefTypeName(ref)
+		if !ok {
+			return file, false
+		}
+
+		gi := m.mappings[idx].goImport
+		content := slices.Delete(slices.Clone(node.Content), i, i+2)
+		node.Content = append(content,
+			scalarNode(extPropGoType), scalarNode(gi.Name+"."+typeName),
+			scalarNode(extPropGoImport), &yaml.Node{
+				Kind: yaml.MappingNode,
+				Tag:  "!!map",
+				Content: []*yaml.Node{
+					scalarNode("path"), scalarNode(gi.Path),
+					scalarNode("name"), scalarNode(gi.Name),
+				},
+			},
+		)
+		return "", true
+	}
+	return "", false
+}
+
+// mapFiles rewrites the mapped $refs of the root spec and of the local files it references, recursively.
+// Files are keyed by their path relative to the root spec directory.
+// The returned flag is set if any of the referenced files was rewritten, not only the root spec.
+func (m *importMapper) mapFiles(rootDir, rootName string, rootContents []byte) (map[string][]byte, bool) {
+	files := make(map[string][]byte)
+	nestedChanged := false
+	queue := []string{rootName}
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		if _, ok := files[name]; ok {
+			continue
+		}
+
+		contents := rootContents
+		if name != rootName {
+			// #nosec G304 -- spec files are user-specified
+			data, err := os.ReadFile(filepath.Join(rootDir, filepath.FromSlash(name)))
+			if err != nil {
+				// Let the regular loading report the missing file
+				continue
+			}
+			contents = data
+		}
+
+		res, refs := m.rewrite(contents, path.Dir(name))
+		if res != nil {
+			contents = res
+			nestedChanged = nestedChanged || name != rootName
+		}
+		files[name] = contents
+		queue = append(queue, refs...)
+	}
+	return files, nestedChanged
+}
+
+// writeMappedFiles writes the rewritten spec files into a temporary directory, keeping their layout,
+// and returns the directory of the root spec in it.
+func writeMappedFiles(files map[string][]byte) (string, func(), error) {
+	tmpDir, err := os.MkdirTemp("", "oapi-codegen-")
+	if err != nil {
+		return "", nil, err
+	}
+	cleanup := func() { _ = os.RemoveAll(tmpDir) }
+
+	// Files can be referenced from the parent directories of the root spec
+	depth := 0
+	for name := range files {
+		depth = max(depth, strings.Count(name, "../"))
+	}
+	rootDir := tmpDir
+	for range depth {
+		rootDir = filepath.Join(rootDir, "_")
+	}
+
+	for name, contents := range files {
+		target := filepath.Join(rootDir, filepath.FromSlash(name))
+		if err = os.MkdirAll(filepath.Dir(target), 0750); err != nil {
+			cleanup()
+			return "", nil, err
+		}
+		if err = os.WriteFile(target, contents, 0600); err != nil {
+			cleanup()
+			return "", nil, err
+		}
+	}
+	return rootDir, cleanup, nil
+}
+
+// resolveRef returns the $ref with its file path relative to the root spec directory, and that file path.
+// dir is the directory of the file containing the $ref. Local and remote references are returned as is.
+func resolveRef(ref, dir string) (string, string) {
+	file, pointer, hasPointer := strings.Cut(ref, "#")
+	if file == "" || strings.Contains(file, "://") || path.IsAbs(file) {
+		return ref, ""
+	}
+
+	file = path.Join(dir, file)
+	if hasPointer {
+		return file + "#" + pointer, file
+	}
+	return file, file
+}
+
+// mappedRefTypeName returns the Go type name of the schema a mapped $ref points to:
+// the schema name for schema references, the file name if the whole file is referenced.
+// Names are the same as the ones the referenced definitions get when the external files are loaded.
+func (n *namer) mappedRefTypeName(ref string) (string, bool) {
+	file, pointer, _ := strings.Cut(ref, "#")
+	if pointer == "" {
+		name := path.Base(file)
+		name = strings.TrimSuffix(name, path.Ext(name))
+		if name == "" || name == "." || name == "/" {
+			return "", false
+		}
+		return n.componentSchemaTypeName(name), true
+	}
+
+	parts := strings.Split(strings.TrimPrefix(pointer, "/"), "/")
+	var name string
+	switch {
+	case len(parts) == 3 && parts[0] == "components" && parts[1] == "schemas":
+		name = parts[2]
+	case len(parts) == 2 && parts[0] == "definitions":
+		name = parts[1]
+	case len(parts) == 1 && parts[0] != "":
+		// Top level definition of a schemas file
+		name = parts[0]
+	default:
+		return "", false
+	}
+	name = strings.ReplaceAll(strings.ReplaceAll(name, "~1", "/"), "~0", "~")
+	return n.componentSchemaTypeName(name), true
+}
+
+func scalarNode(value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+}
@@ -0,0 +1,213 @@
+// Copyright 2025 DoorDash, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package codegen
+
+import (
+	"go/format"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseImportMapping(t *testing.T) {
+	t.Run("sorted by longest prefix", func(t *testing.T) {
+		res, err := parseImportMapping(map[string]string{
+			"./common/":          "example.com/common",
+			"common/money.yaml":  "cash example.com/money",
+			"common/errors.yaml": "-",
+		})
+		require.NoError(t, err)
+		require.Len(t, res, 3)
+
+		assert.Equal(t, importMapping{prefix: "common/errors.yaml", goImport: goImport{Name: "-", Path: "-"}}, res[0])
+		assert.Equal(t, importMapping{prefix: "common/money.yaml", goImport: goImport{Name: "cash", Path: "example.com/money"}}, res[1])
+		assert.Equal(t, importMapping{prefix: "common/", goImport: goImport{Name: "common", Path: "example.com/common"}}, res[2])
+	})
+
+	t.Run("package name of the import path", func(t *testing.T) {
+		res, err := parseImportMapping(map[string]string{"common.yaml": "example.com/common-types/v2"})
+		require.NoError(t, err)
+		require.Len(t, res, 1)
+		assert.Equal(t, goImport{Name: "commontypes", Path: "example.com/common-types/v2"}, res[0].goImport)
+	})
+
+	t.Run("invalid value", func(t *testing.T) {
+		_, err := parseImportMapping(map[string]string{"common.yaml": "a b c"})
+		require.ErrorIs(t, err, ErrImportMappingInvalid)
+	})
+
+	t.Run("empty prefix", func(t *testing.T) {
+		_, err := parseImportMapping(map[string]string{"": "example.com/common"})
+		require.ErrorIs(t, err, ErrImportMappingInvalid)
+	})
+}
+
+func TestImportPathPackageName(t *testing.T) {
+	tests := map[string]string{
+		"example.com/common":       "common",
+		"example.com/common-types": "commontypes",
+		"example.com/api/v2":       "api",
+		"example.com/my-api/v3":    "myapi",
+		"gopkg.in/yaml.v3":         "yaml",
+		"example.com/v2":           "examplecom",
+		"v2":                       "v2",
+		"example.com/3d":           "pkg3d",
+		"example.com/types/type":   "typepkg",
+		"example.com/errors/error": "errorpkg",
+		"example.com/api_types/":   "api_types",
+		"example.com/---":          "pkg",
+	}
+	for importPath, expected := range tests {
+		t.Run(importPath, func(t *testing.T) {
+			assert.Equal(t, expected, importPathPackageName(importPath))
+		})
+	}
+}
+
+func TestImportMappingMatch(t *testing.T) {
+	file := importMapping{prefix: "common.yaml"}
+	dir := importMapping{prefix: "../common/"}
+
+	tests := []struct {
+		mapping  importMapping
+		ref      string
+		expected bool
+	}{
+		{file, "common.yaml", true},
+		{file, "./common.yaml#/components/schemas/Money", true},
+		{file, "common.yaml.bak", false},
+		{file, "#/components/schemas/Money", false},
+		{dir, "../common/common.yaml#/components/schemas/Money", true},
+		{dir, "../commons/common.yaml", false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.ref, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.mapping.match(tc.ref))
+		})
+	}
+}
+
+func TestResolveRef(t *testing.T) {
+	tests := []struct {
+		ref      string
+		dir      string
+		expected string
+		file     string
+	}{
+		{"#/components/schemas/Pet", "paths", "#/components/schemas/Pet", ""},
+		{"./common.yaml#/components/schemas/Money", ".", "common.yaml#/components/schemas/Money", "common.yaml"},
+		{"../schemas/pet.yml#/Pet", "paths", "schemas/pet.yml#/Pet", "schemas/pet.yml"},
+		{"../../common/address.yaml", "schemas", "../common/address.yaml", "../common/address.yaml"},
+		{"https://example.com/common.yaml#/Money", "paths", "https://example.com/common.yaml#/Money", ""},
+	}
+	for _, tc := range tests {
+		t.Run(tc.ref, func(t *testing.T) {
+			ref, file := resolveRef(tc.ref, tc.dir)
+			assert.Equal(t, tc.expected, ref)
+			assert.Equal(t, tc.file, file)
+		})
+	}
+}
+
+func TestCleanRefPrefix(t *testing.T) {
+	tests := map[string]string{
+		"./common/":                    "common/",
+		"specs/../common.yaml":         "common.yaml",
+		"common.yaml#/components/":     "common.yaml#/components/",
+		"https://example.com/./common": "https://example.com/./common",
+	}
+	for prefix, expected := range tests {
+		t.Run(prefix, func(t *testing.T) {
+			assert.Equal(t, expected, cleanRefPrefix(prefix))
+		})
+	}
+}
+
+func TestMappedRefTypeName(t *testing.T) {
+	tests := map[string]string{
+		"common.yaml#/components/schemas/Money":                   "Money",
+		"common.yaml#/components/schemas/user_id":                 "UserID",
+		"common.yaml#/components/schemas/a~1b":                    "AB",
+		"swagger.yaml#/definitions/Problem":                       "Problem",
+		"../schemas/shipping-address.yaml":                        "ShippingAddress",
+		"common.yaml#/components/responses/NotFound":              "",
+		"common.yaml#/components/schemas/Money/properties/amount": "",
+	}
+	for ref, expected := range tests {
+		t.Run(ref, func(t *testing.T) {
+			name, ok := defaultNamer.mappedRefTypeName(ref)
+			assert.Equal(t, expected != "", ok)
+			assert.Equal(t, expected, name)
+		})
+	}
+}
+
+func TestImportMapping(t *testing.T) {
+	spec := []byte(readTestdata(t, "import-mapping.yml"))
+	newConfig := func() Configuration {
+		return Configuration{
+			PackageName: "orders",
+			Output: &Output{
+				UseSingleFile: true,
+			},
+			Generate: &GenerateOptions{
+				Client: true,
+			},
+			ImportMapping: map[string]string{
+				"../common/": "example.com/app/common",
+			},
+		}
+	}
+
+	t.Run("uses the mapped package", func(t *testing.T) {
+		codes, err := Generate(spec, newConfig())
+		require.NoError(t, err)
+
+		code := codes.GetCombined()
+		assert.Contains(t, code, `"example.com/app/common"`)
+		assert.Regexp(t, `Total\s+common.Money\s+`+"`json:\"total\"`", code)
+		assert.Regexp(t, `Lines\s+\[\]common.Money\s+`, code)
+		assert.Regexp(t, `Extras\s+map\[string\]common.Money\s+`, code)
+		assert.Regexp(t, `ShipTo\s+\*common.Address\s+`, code)
+		assert.Contains(t, code, "type GetOrderTotalResponse = common.Money")
+		assert.Contains(t, code, "type GetOrderErrorResponse common.Problem")
+
+		// mapped schemas are not generated again
+		assert.NotContains(t, code, "type Money ")
+		assert.NotContains(t, code, "type Address ")
+
+		_, err = format.Source([]byte(code))
+		require.NoError(t, err, "Generated code should compile without syntax errors")
+	})
+
+	t.Run("package name and type suffix", func(t *testing.T) {
+		cfg := newConfig()
+		cfg.ImportMapping = map[string]string{
+			"../common/": "shared example.com/app/common",
+		}
+		cfg.Naming = &NamingOptions{TypeSuffix: "Model"}
+
+		codes, err := Generate(spec, cfg)
+		require.NoError(t, err)
+
+		code := codes.GetCombined()
+		assert.Contains(t, code, `shared "example.com/app/common"`)
+		assert.Regexp(t, `Total\s+shared.MoneyModel\s+`, code)
+	})
+
+	t.Run("invalid mapping", func(t *testing.T) {
+		cfg := newConfig()
+		cfg.ImportMapping = map[string]string{"../common/": ""}
+		_, err := Generate(spec, cfg)
+		require.ErrorIs(t, err, ErrImportMappingInvalid)
+	})
+}
@@ -31,6 +31,7 @@ import (
 	"mime"
 	"net/url"
 	"regexp"
+	"slices"
 	"sort"
 	"strings"
 	"unicode"
@@ -44,8 +45,6 @@ var (
 	pathParamRE         *regexp.Regexp
 	predeclaredSet      map[string]struct{}
 	separatorSet        map[rune]struct{}
-	nameNormalizer      = toCamelCaseWithInitialism
-	initialismMap       = makeInitialismMap(initialismList)
 	camelCaseMatchParts = regexp.MustCompile(`[\p{Lu}\d]+([\p{Ll}\d]+|$)`)
 	numericPattern      = regexp.MustCompile(`^\d+$`)
 )
@@ -58,7 +57,10 @@ var initialismList = []string{
 }
 
 // targetWordRegex is a regex that matches all initialisms.
-var targetWordRegex *regexp.Regexp
+var targetWordRegex = regexp.MustCompile(`(?i)(` + strings.Join(initialismList, "|") + `)`)
+
+// defaultNamer generates names with the default naming configuration.
+var defaultNamer, _ = newNamer(nil)
 
 func init() {
 	pathParamRE = regexp.MustCompile(`{[.;?]?([^{}*]+)\*?}`)
@@ -165,29 +167,107 @@ func toCamelCase(str string) string {
 	return res.String()
 }
 
+// toStrictCamelCase will convert query-arg style strings to CamelCase, capitalizing every word, initialisms included.
+// So, HTTPServerID would be converted to HttpServerId
+func toStrictCamelCase(s string) string {
+	words := splitCamelCaseWords(toCamelCase(s))
+	for i, word := range words {
+		runes := []rune(strings.ToLower(word))
+		runes[0] = unicode.ToUpper(runes[0])
+		words[i] = string(runes)
+	}
+	return strings.Join(words, "")
+}
+
+// splitCamelCaseWords splits a CamelCase string into its words.
+// An upper case letter starts a word after a lower case letter or a digit, or before a lower case letter:
+// HTTPServerID is split into HTTP, Server and ID.
+func splitCamelCaseWords(s string) []string {
+	runes := []rune(s)
+
+	var (
+		words []string
+		start int
+	)
+	for i := 1; i < len(runes); i++ {
+		if !unicode.IsUpper(runes[i]) {
+			continue
+		}
+		prev := runes[i-1]
+		nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+		if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextIsLower) {
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+	}
+	if start < len(runes) {
+		words = append(words, string(runes[start:]))
+	}
+	return words
+}
+
+// namer generates Go names with a naming configuration.
+// It's resolved once per generation, so generations with different configurations can run concurrently.
+type namer struct {
+	opts        NamingOptions
+	normalizer  func(string) string
+	initialisms map[string]string
+}
+
+// newNamer returns the namer of the naming configuration. A nil configuration uses the defaults.
+func newNamer(opts *NamingOptions) (*namer, error) {
+	if opts == nil {
+		opts = &NamingOptions{}
+	}
+
+	initialisms := slices.DeleteFunc(slices.Clone(initialismList), func(initialism string) bool {
+		return slices.ContainsFunc(opts.Initialisms.Remove, func(removed string) bool {
+			return strings.EqualFold(removed, initialism)
+		})
+	})
+	for _, initialism := range opts.Initialisms.Add {
+		initialisms = append(initialisms, strings.ToUpper(initialism))
+	}
+
+	n := &namer{
+		opts:        *opts,
+		initialisms: makeInitialismMap(initialisms),
+	}
+	switch opts.Normalizer {
+	case "", NameNormalizerInitialisms:
+		n.normalizer = n.toCamelCaseWithInitialism
+	case NameNormalizerLegacy:
+		n.normalizer = toCamelCase
+	case NameNormalizerCamel:
+		n.normalizer = toStrictCamelCase
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrNameNormalizerUnsupported, opts.Normalizer)
+	}
+	return n, nil
+}
+
+// normalize converts a name to a Go name with the configured normalizer.
+func (n *namer) normalize(name string) string {
+	return n.normalizer(name)
+}
+
 // toCamelCaseWithInitialism function will convert query-arg style strings to CamelCase with initialisms in uppercase.
 // So, httpOperationId would be converted to HTTPOperationID
-func toCamelCaseWithInitialism(s string) string {
+func (n *namer) toCamelCaseWithInitialism(s string) string {
 	parts := camelCaseMatchParts.FindAllString(toCamelCase(s), -1)
 	for i := range parts {
-		if v, ok := initialismMap[strings.ToLower(parts[i])]; ok {
+		if v, ok := n.initialisms[strings.ToLower(parts[i])]; ok {
 			parts[i] = v
 		}
 	}
 	return strings.Join(parts, "")
 }
 
-func makeInitialismMap(additionalInitialisms []string) map[string]string {
-	l := append(initialismList, additionalInitialisms...)
-
+func makeInitialismMap(l []string) map[string]string {
 	m := make(map[string]string, len(l))
 	for i := range l {
 		m[strings.ToLower(l[i])] = l[i]
 	}
-
-	// Create a regex to match the initialisms
-	targetWordRegex = regexp.MustCompile(`(?i)(` + strings.Join(l, "|") + `)`)
-
 	return m
 }
 
@@ -204,14 +284,14 @@ func replaceInitialism(s string) string {
 }
 
 // mediaTypeToCamelCase converts a media type to a PascalCase representation
-func mediaTypeToCamelCase(s string) string {
+func (n *namer) mediaTypeToCamelCase(s string) string {
 	// toCamelCase doesn't - and won't - add `/` to the characters it'll allow word boundary
 	s = strings.Replace(s, "/", "_", 1)
 	// including a _ to make sure that these are treated as word boundaries by `toCamelCase`
 	s = strings.Replace(s, "*", "Wildcard_", 1)
 	s = strings.Replace(s, "+", "Plus_", 1)
 
-	return toCamelCaseWithInitialism(s)
+	return n.toCamelCaseWithInitialism(s)
 }
 
 // sortedMapKeys takes a map with keys of type string and returns a slice of those
@@ -246,7 +326,7 @@ func refPathToObjName(refPath string) string {
 // #/components/responses/Baz -> Baz
 // #/paths/~1api~1v1~1foo/get/responses/200/content/application~1json/schema/properties/time -> GetApiV1FooResponse200_Schema_Properties_Time
 // Remote components (document.json#/Foo) are not supported
-func refPathToGoType(refPath string) (string, error) {
+func (n *namer) refPathToGoType(refPath string) (string, error) {
 	if refPath == "" {
 		return "", ErrEmptyReferencePath
 	}
@@ -261,12 +341,15 @@ func refPathToGoType(refPath string) (string, error) {
 	// Standard component references: #/components/schemas/Foo
 	if depth == 4 && pathParts[1] == "components" {
 		lastPart := pathParts[len(pathParts)-1]
-		return schemaNameToTypeName(lastPart), nil
+		if pathParts[2] == "schemas" {
+			return n.componentSchemaTypeName(lastPart), nil
+		}
+		return n.schemaNameToTypeName(lastPart), nil
 	}
 
 	// Deep path references (e.g., inline schemas in paths/responses/properties)
 	// Generate a meaningful name from the path structure
-	return generateTypeNameFromPath(pathParts), nil
+	return n.generateTypeNameFromPath(pathParts), nil
 }
 
 // generateTypeNameFromPath creates a type name from a deep JSON pointer path.
@@ -274,7 +357,7 @@ func refPathToGoType(refPath string) (string, error) {
 //
 //	#/paths/~1api~1v1~1foo/get/responses/200/content/application~1json/schema -> GetApiV1FooResponse200Schema
 //	#/paths/~1api~1v1~1foo/get/responses/200/content/application~1json/schema/properties/time -> GetApiV1FooResponse200Schema_Time
-func generateTypeNameFromPath(pathParts []string) string {
+func (n *namer) generateTypeNameFromPath(pathParts []string) string {
 	if len(pathParts) < 2 {
 		return "Schema"
 	}
@@ -364,7 +447,7 @@ func generateTypeNameFromPath(pathParts []string) string {
 	}
 
 	// Convert to a valid Go type name using pathToTypeName
-	return pathToTypeName(nameParts)
+	return n.pathToTypeName(nameParts)
 }
 
 // orderedParamsFromUri returns the argument names, in order, in a given URI string, so for
@@ -530,28 +613,38 @@ func typeNamePrefixInternal(name string, handleDigits bool) (prefix string) {
 
 // schemaNameToTypeName converts a GoSchema name to a valid Go type name.
 // It converts to camel case, and makes sure the name is valid in Go
-func schemaNameToTypeName(name string) string {
+func (n *namer) schemaNameToTypeName(name string) string {
 	// Handle parameter names ending with [] (e.g., "dataSegmentCode[]")
 	// These are typically array parameters in query strings
 	// We append "Array" suffix to distinguish them from the singular version
+	original := name
 	arraySuffix := ""
 	if strings.HasSuffix(name, "[]") {
 		name = strings.TrimSuffix(name, "[]")
 		arraySuffix = "Array"
 	}
-	return typeNamePrefix(name) + nameNormalizer(name) + arraySuffix
+	typeName := typeNamePrefix(name) + n.normalize(name) + arraySuffix
+	if hooks := n.opts.Hooks; hooks != nil && hooks.TypeName != nil {
+		return hooks.TypeName(original, typeName)
+	}
+	return typeName
+}
+
+// componentSchemaTypeName returns the type name of a component schema, with the configured prefix and suffix.
+func (n *namer) componentSchemaTypeName(name string) string {
+	return n.opts.TypePrefix + n.schemaNameToTypeName(name) + n.opts.TypeSuffix
 }
 
 // pathToTypeName converts a path, like Object/field1/nestedField into a go
 // type name.
-func pathToTypeName(path []string) string {
+func (n *namer) pathToTypeName(path []string) string {
 	for i, p := range path {
 		// Only add prefix for special characters and digits at the start of the first segment
 		// For subsequent segments, only handle special characters, not leading digits
 		if i == 0 {
-			path[i] = typeNamePrefix(p) + nameNormalizer(p)
+			path[i] = typeNamePrefix(p) + n.normalize(p)
 		} else {
-			path[i] = typeNamePrefixNonDigit(p) + nameNormalizer(p)
+			path[i] = typeNamePrefixNonDigit(p) + n.normalize(p)
 		}
 	}
 	return strings.Join(path, "_")
@@ -623,14 +716,14 @@ func escapePathElements(path string) string {
 // and the definition of the schema. If the schema overrides the name via
 // x-go-name, the new name is returned, otherwise, the original name is
 // returned.
-func renameComponent(schemaName string, schemaRef *base.SchemaProxy) (string, error) {
+func (n *namer) renameComponent(schemaName string, schemaRef *base.SchemaProxy) (string, error) {
 	if schemaRef == nil {
 		return schemaName, nil
 	}
 
 	// References will not change type names.
 	if schemaRef.IsReference() {
-		return schemaNameToTypeName(schemaName), nil
+		return n.schemaNameToTypeName(schemaName), nil
 	}
 
 	// Try to get x-go-name from low-level schema extensions without triggering full schema parsing.
@@ -654,9 +747,9 @@ func renameComponent(schemaName string, schemaRef *base.SchemaProxy) (string, er
 }
 
 // renameParameter generates the name for a parameter, taking x-go-name into account
-func renameParameter(parameterName string, parameterRef *v3.Parameter) (string, error) {
+func (n *namer) renameParameter(parameterName string, parameterRef *v3.Parameter) (string, error) {
 	if parameterRef.Schema != nil && parameterRef.Schema.IsReference() {
-		return schemaNameToTypeName(parameterName), nil
+		return n.schemaNameToTypeName(parameterName), nil
 	}
 	parameter := parameterRef
 
@@ -668,7 +761,7 @@ func renameParameter(parameterName string, parameterRef *v3.Parameter) (string,
 		}
 		return typeName, nil
 	}
-	return schemaNameToTypeName(parameterName), nil
+	return n.schemaNameToTypeName(parameterName), nil
 }
 
 func isMediaTypeJson(mediaType string) bool {
@@ -678,3 +771,12 @@ func isMediaTypeJson(mediaType string) bool {
 	}
 	return parsed == "application/json" || strings.HasSuffix(parsed, "+json")
 }
+
+// isMediaTypeXML returns true for XML media types, e.g. application/xml, text/xml or application/soap+xml.
+func isMediaTypeXML(mediaType string) bool {
+	parsed, _, err := mime.ParseMediaType(mediaType)
+	if err != nil {
+		return false
+	}
+	return parsed == "application/xml" || parsed == "text/xml" || strings.HasSuffix(parsed, "+xml")
+}
@@ -121,11 +121,65 @@ func TestToCamelCaseWithInitialisms(t *testing.T) {
 	for i := range tests {
 		tt := tests[i]
 		t.Run(tt.str, func(t *testing.T) {
-			require.Equal(t, tt.want, toCamelCaseWithInitialism(tt.str))
+			require.Equal(t, tt.want, defaultNamer.toCamelCaseWithInitialism(tt.str))
 		})
 	}
 }
 
+func TestToStrictCamelCase(t *testing.T) {
+	tests := []struct {
+		str  string
+		want string
+	}{
+		{"", ""},
+		{"hello", "Hello"},
+		{"httpOperationId", "HttpOperationId"},
+		{"HTTPServerID", "HttpServerId"},
+		{"find_user_by_uuid", "FindUserByUuid"},
+		{"makeUTF8Value", "MakeUtf8Value"},
+		{"peer2peer", "Peer2Peer"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.str, func(t *testing.T) {
+			assert.Equal(t, tt.want, toStrictCamelCase(tt.str))
+		})
+	}
+}
+
+func TestNewNamer(t *testing.T) {
+	t.Run("initialisms", func(t *testing.T) {
+		n, err := newNamer(&NamingOptions{
+			Initialisms: InitialismOptions{
+				Add:    []string{"sku", "IBAN"},
+				Remove: []string{"ts"},
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "ProductSKU", n.schemaNameToTypeName("product_sku"))
+		assert.Equal(t, "IBANAccount", n.schemaNameToTypeName("iban_account"))
+		assert.Equal(t, "CreatedTs", n.schemaNameToTypeName("created_ts"))
+		assert.Equal(t, "UserID", n.schemaNameToTypeName("user_id"))
+	})
+
+	t.Run("normalizers", func(t *testing.T) {
+		n, err := newNamer(&NamingOptions{Normalizer: NameNormalizerLegacy})
+		require.NoError(t, err)
+		assert.Equal(t, "UserId", n.schemaNameToTypeName("user_id"))
+
+		n, err = newNamer(&NamingOptions{Normalizer: NameNormalizerCamel})
+		require.NoError(t, err)
+		assert.Equal(t, "HttpServerId", n.schemaNameToTypeName("HTTPServerID"))
+
+		_, err = newNamer(&NamingOptions{Normalizer: "snake"})
+		require.ErrorIs(t, err, ErrNameNormalizerUnsupported)
+	})
+
+	t.Run("defaults are unchanged", func(t *testing.T) {
+		assert.Equal(t, "CreatedTS", defaultNamer.schemaNameToTypeName("created_ts"))
+		assert.Equal(t, "ProductSku", defaultNamer.schemaNameToTypeName("product_sku"))
+	})
+}
+
 func TestRefPathToGoType(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -180,7 +234,7 @@ func TestRefPathToGoType(t *testing.T) {
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			goType, err := refPathToGoType(tc.path)
+			goType, err := defaultNamer.refPathToGoType(tc.path)
 			if tc.goType == "" {
 				assert.Error(t, err)
 				return
@@ -345,7 +399,7 @@ func TestSchemaNameToTypeName(t *testing.T) {
 		"<":            "LessThan",
 		">":            "GreaterThan",
 	} {
-		assert.Equal(t, want, schemaNameToTypeName(in))
+		assert.Equal(t, want, defaultNamer.schemaNameToTypeName(in))
 	}
 }
 
@@ -462,7 +516,7 @@ func TestPathToTypeName(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := pathToTypeName(tt.path)
+			got := defaultNamer.pathToTypeName(tt.path)
 			assert.Equal(t, tt.want, got)
 		})
 	}
@@ -574,7 +628,7 @@ func TestGenerateTypeNameFromPath(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := generateTypeNameFromPath(tt.pathParts)
+			got := defaultNamer.generateTypeNameFromPath(tt.pathParts)
 			assert.Equal(t, tt.want, got)
 		})
 	}
@@ -12,51 +12,91 @@ package codegen
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 	"unicode"
 
 	"github.com/pb33f/libopenapi"
+	"github.com/pb33f/libopenapi/bundler"
 	"github.com/pb33f/libopenapi/datamodel"
+	v3high "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/utils"
 	"go.yaml.in/yaml/v4"
 )
 
 func CreateDocument(docContents []byte, cfg Configuration) (libopenapi.Document, error) {
+	doc, _, err := CreateDocumentWithFilterReport(docContents, cfg)
+	return doc, err
+}
+
+// CreateDocumentWithFilterReport creates the document like CreateDocument,
+// also returning the report of what the filter removed from the spec.
+func CreateDocumentWithFilterReport(docContents []byte, cfg Configuration) (libopenapi.Document, FilterReport, error) {
+	var report FilterReport
+	docContents, err := applyImportMapping(docContents, cfg)
+	if err != nil {
+		return nil, report, fmt.Errorf("error applying import mapping: %w", err)
+	}
+
 	doc, err := LoadDocumentFromContents(docContents)
 	if err != nil {
-		return nil, err
+		return nil, report, err
 	}
 
 	// Apply overlays before filtering and pruning
 	if cfg.Overlay != nil && len(cfg.Overlay.Sources) > 0 {
 		doc, err = applyOverlays(doc, cfg.Overlay.Sources)
 		if err != nil {
-			return nil, fmt.Errorf("error applying overlays: %w", err)
+			return nil, report, fmt.Errorf("error applying overlays: %w", err)
 		}
 	}
 
 	if _, err = doc.BuildV3Model(); err != nil {
-		return nil, fmt.Errorf("error building model: %w", err)
+		return nil, report, fmt.Errorf("error building model: %w", err)
 	}
 
-	var filtered bool
-	model, filtered, err := filterOutDocument(doc, cfg.Filter)
+	model, report, err := filterOutDocument(doc, cfg.Filter)
 	if err != nil {
-		return nil, fmt.Errorf("error filtering document: %w", err)
+		return nil, report, fmt.Errorf("error filtering document: %w", err)
 	}
+	schemas := componentSchemaNames(model)
 
 	// If we filtered anything, we must prune to remove dangling references
 	// Otherwise, only prune if SkipPrune is false
-	if filtered || !cfg.SkipPrune {
+	if !report.IsEmpty() || !cfg.SkipPrune {
 		if err = pruneSchema(model); err != nil {
-			return nil, fmt.Errorf("error pruning schema: %w", err)
+			return nil, report, fmt.Errorf("error pruning schema: %w", err)
 		}
-		return doc, nil
 	}
 
-	return doc, nil
+	remaining := componentSchemaNames(model)
+	for _, name := range schemas {
+		if !slices.Contains(remaining, name) {
+			report.Schemas = append(report.Schemas, name)
+		}
+	}
+
+	return doc, report, nil
 }
 
+func componentSchemaNames(model *v3high.Document) []string {
+	if model.Components == nil || model.Components.Schemas == nil {
+		return nil
+	}
+	return slices.Collect(model.Components.Schemas.KeysFromOldest())
+}
+
+// LoadDocumentFromContents loads an OpenAPI 3 document.
+// Swagger 2.0 documents are converted to OpenAPI 3 first, constructs without an equivalent are dropped.
+// Use ConvertSwagger2 beforehand to get the warnings about them.
 func LoadDocumentFromContents(contents []byte) (libopenapi.Document, error) {
+	contents, _, err := ConvertSwagger2(contents)
+	if err != nil {
+		return nil, err
+	}
+
 	docConfig := &datamodel.DocumentConfiguration{
 		SkipCircularReferenceCheck: true,
 	}
@@ -67,6 +107,114 @@ func LoadDocumentFromContents(contents []byte) (libopenapi.Document, error) {
 	return doc, nil
 }
 
+// LoadSpecFromFile reads the spec at path and resolves its relative external $refs against the spec directory.
+// Externally referenced definitions are lifted into components, named after the referenced definition
+// (or the file name if the whole file is referenced), so they get the same Go type names as if they
+// were declared in the root spec. Specs without external references are returned as is.
+func LoadSpecFromFile(path string) ([]byte, error) {
+	return loadSpecFromFile(path, Configuration{})
+}
+
+// loadSpecFromFile loads the spec at path like LoadSpecFromFile,
+// applying the import mapping of the configuration before external $refs are resolved.
+func loadSpecFromFile(path string, cfg Configuration) ([]byte, error) {
+	contents, _, err := loadSpecFiles(path, cfg)
+	return contents, err
+}
+
+// SpecFiles returns the absolute paths of the local files the spec at path is made of:
+// the spec itself and the files its external $refs resolve to, except the ones mapped to Go packages.
+func SpecFiles(path string, cfg Configuration) ([]string, error) {
+	_, files, err := loadSpecFiles(path, cfg)
+	return files, err
+}
+
+// loadSpecFiles loads the spec at path like loadSpecFromFile, also returning the files it's made of.
+func loadSpecFiles(path string, cfg Configuration) ([]byte, []string, error) {
+	// #nosec G304 -- spec paths are user-specified
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error reading spec: %w", err)
+	}
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error resolving spec path: %w", err)
+	}
+	specDir := filepath.Dir(absPath)
+	basePath := specDir
+	files := []string{absPath}
+
+	mapper, err := newImportMapper(cfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error applying import mapping: %w", err)
+	}
+	if mapper != nil {
+		mapped, nestedChanged := mapper.mapFiles(basePath, filepath.Base(absPath), contents)
+		contents = mapped[filepath.Base(absPath)]
+		if nestedChanged {
+			// The rewritten external files are resolved from a copy of the spec files
+			dir, cleanup, err := writeMappedFiles(mapped)
+			if err != nil {
+				return nil, nil, fmt.Errorf("error applying import mapping: %w", err)
+			}
+			defer cleanup()
+			basePath = dir
+		}
+	}
+
+	docConfig := &datamodel.DocumentConfiguration{
+		BasePath:                   basePath,
+		SpecFilePath:               filepath.Base(absPath),
+		AllowFileReferences:        true,
+		SkipCircularReferenceCheck: true,
+	}
+	doc, err := libopenapi.NewDocumentWithConfiguration(contents, docConfig)
+	if err != nil {
+		if doc, err = fixDocument(contents, err, docConfig); err != nil {
+			return nil, nil, fmt.Errorf("error loading spec: %w", err)
+		}
+	}
+
+	if info := doc.GetSpecInfo(); info != nil && info.SpecType == utils.OpenApi2 {
+		// Swagger 2.0 documents are converted when loaded, the files they reference are not
+		if refs := swaggerExternalRefs(contents); len(refs) > 0 {
+			return nil, nil, fmt.Errorf("external references are not supported in Swagger 2.0 specs, "+
+				"convert the spec to OpenAPI 3 or bundle it first: %s", strings.Join(refs, ", "))
+		}
+		return contents, files, nil
+	}
+
+	model, err := doc.BuildV3Model()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error building model: %w", err)
+	}
+	if model.Model.Rolodex == nil || len(model.Model.Rolodex.GetIndexes()) == 0 {
+		return contents, files, nil
+	}
+
+	for _, idx := range model.Model.Rolodex.GetIndexes() {
+		file := idx.GetSpecAbsolutePath()
+		if file == "" {
+			continue
+		}
+		// Files resolved from the copy made for the import mapping are reported at their original location
+		if rel, err := filepath.Rel(basePath, file); err == nil && basePath != specDir {
+			file = filepath.Join(specDir, rel)
+		}
+		if !slices.Contains(files, file) {
+			files = append(files, file)
+		}
+	}
+
+	bundled, err := bundler.BundleDocumentComposed(&model.Model, &bundler.BundleCompositionConfig{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("error resolving external references: %w", err)
+	}
+
+	return bundled, files, nil
+}
+
 func fixDocument(contents []byte, originalErr error, docConfig *datamodel.DocumentConfiguration) (libopenapi.Document, error) {
 	if !strings.Contains(originalErr.Error(), "unable to parse specification") {
 		return nil, originalErr
@@ -13,8 +13,44 @@ package codegen
 import (
 	"net/http"
 	"strings"
+
+	v3high "github.com/pb33f/libopenapi/datamodel/high/v3"
+)
+
+// OperationKind tells where an operation is declared in the spec.
+type OperationKind string
+
+const (
+	// OperationKindPath is an operation declared under paths.
+	OperationKindPath OperationKind = ""
+
+	// OperationKindWebhook is an operation declared under webhooks.
+	OperationKindWebhook OperationKind = "webhook"
+
+	// OperationKindCallback is an operation declared in the callbacks of another operation.
+	OperationKindCallback OperationKind = "callback"
 )
 
+// operationSource is a path item to collect operations from.
+// For webhooks path is the webhook name and for callbacks the runtime expression.
+// idPath, if set, is used instead of path to create operation IDs.
+type operationSource struct {
+	path     string
+	pathItem *v3high.PathItem
+	kind     OperationKind
+	idPath   string
+
+	// tags are the tags of the parent operation, used by callbacks without tags.
+	tags []string
+}
+
+func (s operationSource) operationIDPath() string {
+	if s.idPath != "" {
+		return s.idPath
+	}
+	return s.path
+}
+
 // OperationDefinition describes an Operation.
 // ID The operation_id description from Swagger, used to generate function names.
 // Summary string from OpenAPI spec, used to generate a comment.
@@ -23,17 +59,21 @@ import (
 // Path The path for this operation.
 // PathParams Parameters in the path
 // Header HTTP headers.
+// Cookies Cookie parameters.
 // Query Query
 // TypeDefinitions These are all the types we need to define for this operation.
 // BodyRequired Whether the body is required for this operation.
 type OperationDefinition struct {
 	ID          string
+	Kind        OperationKind
 	Summary     string
 	Description string
 	Method      string
 	Path        string
+	Tags        []string
 	PathParams  *TypeDefinition
 	Header      *RequestParametersDefinition
+	Cookies     *RequestParametersDefinition
 	Query       *RequestParametersDefinition
 
 	TypeDefinitions []TypeDefinition
@@ -45,13 +85,21 @@ type OperationDefinition struct {
 
 	// MCP contains x-mcp extension configuration for MCP tool generation
 	MCP *MCPExtension
+
+	// Streaming is true if the client returns the success response body as a runtime.StreamResponse
+	// instead of decoding it, either for binary responses or with the x-go-stream extension.
+	Streaming bool
+
+	// Security lists the alternative security requirements of the operation.
+	// Any one of them must be satisfied, it's nil if the operation doesn't require authentication.
+	Security []SecurityRequirement
 }
 
 // RequiresParamObject indicates If we have parameters other than path parameters, they're bundled into an
 // object. Returns true if we have any of those.
 // This is used from the template engine.
 func (o OperationDefinition) RequiresParamObject() bool {
-	return o.Query != nil || o.Header != nil
+	return o.Query != nil || o.Header != nil || o.Cookies != nil
 }
 
 // SummaryAsComment returns the Operations summary as a multi line comment
@@ -74,8 +122,21 @@ func (o OperationDefinition) GetSuccessResponse() string {
 	return o.Response.Success.ResponseName
 }
 
+// ClientResponseType returns the type of the success response returned by the client.
+func (o OperationDefinition) ClientResponseType() string {
+	if o.Streaming {
+		return "runtime.StreamResponse"
+	}
+	return o.Response.Success.ResponseName
+}
+
+// IsEventStream returns true if the success response is a stream of Server-Sent Events.
+func (o OperationDefinition) IsEventStream() bool {
+	return o.Response.Success != nil && o.Response.Success.IsEventStream
+}
+
 func (o OperationDefinition) HasRequestOptions() bool {
-	return o.PathParams != nil || o.Header != nil || o.Query != nil || o.Body != nil
+	return o.PathParams != nil || o.Header != nil || o.Cookies != nil || o.Query != nil || o.Body != nil
 }
 
 // filterParameterDefinitionByType returns the subset of the specified parameters which are of the
@@ -93,9 +154,20 @@ func filterParameterDefinitionByType(params []ParameterDefinition, in string) []
 // createOperationID generates a unique operation ID based on the HTTP method and path.
 // If the initial value is provided, it will be used.
 // The resulting operation ID is a camel-cased string.
-func createOperationID(method, path, initial string) (string, error) {
+func (n *namer) createOperationID(method, path, initial string) (string, error) {
+	operationID, err := n.generateOperationID(method, path, initial)
+	if err != nil {
+		return "", err
+	}
+	if hooks := n.opts.Hooks; hooks != nil && hooks.OperationID != nil {
+		return hooks.OperationID(method, path, initial, operationID), nil
+	}
+	return operationID, nil
+}
+
+func (n *namer) generateOperationID(method, path, initial string) (string, error) {
 	if initial != "" {
-		return typeNamePrefix(initial) + nameNormalizer(initial), nil
+		return typeNamePrefix(initial) + n.normalize(initial), nil
 	}
 
 	if method == "" {
@@ -114,5 +186,5 @@ func createOperationID(method, path, initial string) (string, error) {
 		}
 	}
 
-	return nameNormalizer(res), nil
+	return n.normalize(res), nil
 }
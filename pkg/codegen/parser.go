@@ -16,6 +16,7 @@ import (
 	"embed"
 	"fmt"
 	"go/format"
+	"net/http"
 	"os"
 	"slices"
 	"sort"
@@ -77,12 +78,24 @@ type ParseOptions struct {
 	// Key is the Go struct tag name, value is the OpenAPI schema field to extract.
 	AutoExtraTags map[string]string
 
+	// IncludeDefaultResponse generates a type for the default response
+	// even when explicit error responses are declared.
+	IncludeDefaultResponse bool
+
+	// XMLTags adds xml struct tags to the fields of generated types,
+	// set for the schemas of XML request and response bodies.
+	XMLTags bool
+
 	// runtime options
 	typeTracker  *TypeTracker
+	naming       *namer
 	reference    string
 	path         []string
 	specLocation SpecLocation
 
+	// xmlRefs holds the references of the component schemas used by XML bodies
+	xmlRefs map[string]bool
+
 	// Track visited schema paths to prevent infinite recursion
 	visited map[string]bool
 
@@ -91,11 +104,24 @@ type ParseOptions struct {
 	model *v3high.Document
 }
 
+// namer returns the namer of the naming configuration, the default one if not set.
+func (o ParseOptions) namer() *namer {
+	if o.naming == nil {
+		return defaultNamer
+	}
+	return o.naming
+}
+
 func (o ParseOptions) WithReference(reference string) ParseOptions {
 	o.reference = reference
 	return o
 }
 
+func (o ParseOptions) WithXMLTags(xmlTags bool) ParseOptions {
+	o.XMLTags = xmlTags
+	return o
+}
+
 func (o ParseOptions) WithPath(path []string) ParseOptions {
 	o.path = slices.Clone(path)
 	return o
@@ -130,12 +156,64 @@ type TplTypeContext struct {
 
 // TplOperationsContext is the context passed to templates to generate client code.
 type TplOperationsContext struct {
-	Operations    []OperationDefinition
-	Imports       []string
-	Config        Configuration
-	WithHeader    bool
-	ServerOptions *ServerOptions
-	PackageName   string
+	Operations      []OperationDefinition
+	Imports         []string
+	Config          Configuration
+	WithHeader      bool
+	ServerOptions   *ServerOptions
+	PackageName     string
+	SecuritySchemes []SecuritySchemeDefinition
+	Servers         []ServerDefinition
+
+	// Webhooks holds the webhook and callback operations.
+	Webhooks []OperationDefinition
+
+	// EmbeddedSpec holds the encoded spec lines when generate.embedded-spec is enabled.
+	EmbeddedSpec []string
+}
+
+// AllOperations returns the path operations followed by the webhook and callback operations.
+func (c TplOperationsContext) AllOperations() []OperationDefinition {
+	return append(slices.Clip(c.Operations), c.Webhooks...)
+}
+
+// ServesSpec returns true if the router serves the embedded spec.
+func (c TplOperationsContext) ServesSpec() bool {
+	return c.Config.Generate != nil && c.Config.Generate.EmbeddedSpec &&
+		c.Config.Generate.Handler != nil && c.Config.Generate.Handler.SpecPath != ""
+}
+
+// RouteOperations returns the operations to register with the router,
+// including the route serving the embedded spec if enabled.
+func (c TplOperationsContext) RouteOperations() []OperationDefinition {
+	if !c.ServesSpec() {
+		return c.Operations
+	}
+	return append(slices.Clip(c.Operations), OperationDefinition{
+		ID:     "ServeOpenAPISpec",
+		Method: http.MethodGet,
+		Path:   c.Config.Generate.Handler.SpecPath,
+	})
+}
+
+// HasSecurity returns true if any operation declares security requirements.
+func (c TplOperationsContext) HasSecurity() bool {
+	for _, op := range c.AllOperations() {
+		if len(op.Security) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// HasEventStreams returns true if any operation responds with a stream of Server-Sent Events.
+func (c TplOperationsContext) HasEventStreams() bool {
+	for _, op := range c.AllOperations() {
+		if op.IsEventStream() {
+			return true
+		}
+	}
+	return false
 }
 
 // NewParser creates a new Parser with the provided ParseConfig and ParseContext.
@@ -172,6 +250,10 @@ func NewParser(cfg Configuration, ctx *ParseContext) (*Parser, error) {
 // Parse generates Go code for the API using the provided ParseContext.
 // It returns a map of generated code for each type of definition.
 func (p *Parser) Parse() (GeneratedCode, error) {
+	if p.cfg.Output != nil && p.cfg.Output.SplitBy != "" {
+		return p.parseSplitByTag()
+	}
+
 	typesOut := make(map[string]string)
 	scaffoldOut := make(map[string]string)
 
@@ -205,14 +287,27 @@ func (p *Parser) Parse() (GeneratedCode, error) {
 		}
 	}
 
-	if len(p.ctx.Operations) > 0 && p.cfg.Generate.Client {
+	hasOperations := len(p.ctx.Operations) > 0 || len(p.ctx.Webhooks) > 0
+
+	if hasOperations && p.cfg.Generate.Client {
 		opsCtx := &TplOperationsContext{
-			Operations: p.ctx.Operations,
-			Imports:    p.ctx.Imports,
-			Config:     p.cfg,
-			WithHeader: withHeader,
-		}
-		for _, tmpl := range []string{"client", "client-options"} {
+			Operations:      p.ctx.Operations,
+			Webhooks:        p.ctx.Webhooks,
+			Imports:         p.ctx.Imports,
+			Config:          p.cfg,
+			WithHeader:      withHeader,
+			SecuritySchemes: p.ctx.SecuritySchemes,
+			Servers:         p.ctx.Servers,
+		}
+		var templates []string
+		if len(p.ctx.Operations) > 0 {
+			templates = append(templates, "client")
+		}
+		templates = append(templates, "client-options")
+		if len(p.ctx.Webhooks) > 0 {
+			templates = append(templates, "webhook-sender")
+		}
+		for _, tmpl := range templates {
 			out, err := p.ParseTemplates([]string{tmpl + ".tmpl"}, opsCtx)
 			if err != nil {
 				return nil, fmt.Errorf("error generating code for client: %w", err)
@@ -228,13 +323,57 @@ func (p *Parser) Parse() (GeneratedCode, error) {
 		}
 	}
 
-	// Generate handler code if handler generation is enabled
-	if len(p.ctx.Operations) > 0 && p.cfg.Generate.Handler != nil {
-		opsCtx := &TplOperationsContext{
-			Operations: p.ctx.Operations,
-			Imports:    p.ctx.Imports,
+	if p.cfg.Generate.EmbeddedSpec && len(p.ctx.Spec) > 0 {
+		lines, err := encodeEmbeddedSpec(p.ctx.Spec)
+		if err != nil {
+			return nil, fmt.Errorf("error encoding embedded spec: %w", err)
+		}
+		out, err := p.ParseTemplates([]string{"embedded-spec.tmpl"}, &TplOperationsContext{
+			Config:       p.cfg,
+			WithHeader:   withHeader,
+			EmbeddedSpec: lines,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error generating code for embedded spec: %w", err)
+		}
+		formatted := out
+		if !useSingleFile {
+			formatted, err = FormatCode(out)
+			if err != nil {
+				return nil, fmt.Errorf("error formatting embedded spec: %w", err)
+			}
+		}
+		typesOut["embedded_spec"] = formatted
+	}
+
+	if hasOperations && p.cfg.Generate.Observability.Enabled() && (p.cfg.Generate.Client || p.cfg.Generate.Handler != nil) {
+		out, err := p.ParseTemplates([]string{"observability.tmpl"}, &TplOperationsContext{
+			Webhooks:   p.ctx.Webhooks,
 			Config:     p.cfg,
 			WithHeader: withHeader,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error generating code for observability: %w", err)
+		}
+		formatted := out
+		if !useSingleFile {
+			formatted, err = FormatCode(out)
+			if err != nil {
+				return nil, fmt.Errorf("error formatting observability: %w", err)
+			}
+		}
+		typesOut["observability"] = formatted
+	}
+
+	// Generate handler code if handler generation is enabled
+	if hasOperations && p.cfg.Generate.Handler != nil {
+		opsCtx := &TplOperationsContext{
+			Operations:      p.ctx.Operations,
+			Webhooks:        p.ctx.Webhooks,
+			Imports:         p.ctx.Imports,
+			Config:          p.cfg,
+			WithHeader:      withHeader,
+			SecuritySchemes: p.ctx.SecuritySchemes,
 		}
 		// Determine which templates to use based on handler kind
 		handlerKind := p.cfg.Generate.Handler.Kind
@@ -252,6 +391,10 @@ func (p *Parser) Parse() (GeneratedCode, error) {
 		} else {
 			// In multi-file mode, generate separate files from shared templates
 			for _, tmpl := range []string{"errors", "adapter", "router"} {
+				// Webhooks are served by the adapter only, there are no routes for them
+				if tmpl == "router" && len(p.ctx.Operations) == 0 {
+					continue
+				}
 				out, err := p.ParseTemplates([]string{sharedPrefix + tmpl + ".tmpl"}, opsCtx)
 				if err != nil {
 					return nil, fmt.Errorf("error generating code for %s: %w", tmpl, err)
@@ -288,7 +431,7 @@ func (p *Parser) Parse() (GeneratedCode, error) {
 		}
 
 		// Generate middleware if enabled - scaffolded file
-		if p.cfg.Generate.Handler.Middleware != nil {
+		if p.cfg.Generate.Handler.Middleware != nil && len(p.ctx.Operations) > 0 {
 			middlewareCtx := &TplOperationsContext{
 				Operations:  p.ctx.Operations,
 				Imports:     p.ctx.Imports,
@@ -346,7 +489,7 @@ func (p *Parser) Parse() (GeneratedCode, error) {
 		scaffoldOut[serviceKey] = formatted
 
 		// Generate server main.go if server generation is enabled - scaffolded file
-		if p.cfg.Generate.Handler.Server != nil {
+		if p.cfg.Generate.Handler.Server != nil && len(p.ctx.Operations) > 0 {
 			serverOpts := p.cfg.Generate.Handler.Server.WithDefaults()
 			if err := serverOpts.Validate(); err != nil {
 				return nil, fmt.Errorf("invalid server options: %w", err)
@@ -572,7 +715,12 @@ func (p *Parser) ParseTemplates(templates []string, data any) (string, error) {
 }
 
 func loadTemplates(cfg Configuration) (*template.Template, error) {
-	tpl := template.New("templates").Funcs(TemplateFunctions)
+	naming, err := newNamer(cfg.Naming)
+	if err != nil {
+		return nil, err
+	}
+	// genTypeName names types the way the configured naming does
+	tpl := template.New("templates").Funcs(TemplateFunctions).Funcs(template.FuncMap{"genTypeName": naming.normalize})
 
 	// Load templates from specific directories in order:
 	// 1. Root templates (templates/*.tmpl)
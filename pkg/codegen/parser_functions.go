@@ -24,7 +24,7 @@ import (
 // TemplateFunctions is passed to the template engine, and we can call each
 // function here by keyName from the template code.
 var TemplateFunctions = template.FuncMap{
-	"genTypeName":    nameNormalizer,
+	"genTypeName":    defaultNamer.normalize,
 	"lcFirst":        lowercaseFirstCharacter,
 	"ucFirst":        uppercaseFirstCharacter,
 	"caps":           strings.ToUpper,
@@ -36,6 +36,7 @@ var TemplateFunctions = template.FuncMap{
 	"fst":            fst,
 	"hasPrefix":      strings.HasPrefix,
 	"hasSuffix":      strings.HasSuffix,
+	"isXML":          isMediaTypeXML,
 	"contains":       strings.Contains,
 	"str":            str,
 	"dict":           dict,
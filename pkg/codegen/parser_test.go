@@ -218,4 +218,31 @@ type AddressOneOf struct {
 			assert.Contains(t, res, expected, "failed expected %d", i+1)
 		}
 	})
+
+	t.Run("discriminator value without union element", func(t *testing.T) {
+		schema := GoSchema{
+			UnionElements: []UnionElement{
+				{TypeName: "Cat", Schema: GoSchema{GoType: "Cat"}},
+				{TypeName: "Dog", Schema: GoSchema{GoType: "Dog"}},
+				{TypeName: "Bird", Schema: GoSchema{GoType: "Bird"}},
+			},
+			Discriminator: &Discriminator{
+				Property: "kind",
+				Mapping:  map[string]string{"cat": "Cat", "dog": "Dog", "bird": "Bird", "fish": "Fish"},
+			},
+		}
+		schema.GoType = schema.createGoStruct(genFieldsFromProperties(schema.Properties, parseOptions))
+
+		parseCtx := &ParseContext{
+			UnionTypes: []TypeDefinition{{Name: "Pet", SpecLocation: SpecLocationUnion, Schema: schema}},
+		}
+		parser, err := NewParser(cfg, parseCtx)
+		require.NoError(t, err)
+		codes, err := parser.Parse()
+		require.NoError(t, err)
+
+		res := codes.GetCombined()
+		assert.Regexp(t, `case "cat":\s+_, err = p.AsValidatedCat\(\)`, res)
+		assert.Regexp(t, `case "fish":\s+return runtime.NewValidationErrorsFromString\("kind", "discriminator value "\+discriminator\+" maps to Fish, which is not a variant of the union"\)`, res)
+	})
 }
@@ -21,12 +21,11 @@ import (
 )
 
 func pruneSchema(model *v3high.Document) error {
-	// Aggressively remove everything we don't generate code for
-	slog.Debug("Pruning: removing webhooks, security schemes, callbacks, component examples, links")
-	model.Webhooks = nil
+	// Aggressively remove everything we don't generate code for.
+	// Operation callbacks are already resolved, so the component callbacks aren't needed.
+	slog.Debug("Pruning: removing component callbacks, examples, links")
 	if model.Components != nil {
 		// Set to nil - we don't generate code for these
-		model.Components.SecuritySchemes = nil
 		model.Components.Callbacks = nil
 		model.Components.Examples = nil
 		model.Components.Links = nil
@@ -113,6 +112,16 @@ func removeOrphanedComponents(model *v3high.Document, refs map[string]bool) int
 		}
 	}
 
+	if model.Components.SecuritySchemes != nil {
+		for _, key := range getComponentKeys(model.Components.SecuritySchemes.KeysFromOldest()) {
+			ref := fmt.Sprintf("#/components/securitySchemes/%s", key)
+			if !refs[ref] {
+				countRemoved++
+				model.Components.SecuritySchemes.Delete(key)
+			}
+		}
+	}
+
 	// Note: Links, Callbacks, Examples are set to nil in pruneSchema, so we don't need to prune them here
 
 	return countRemoved
@@ -121,38 +130,20 @@ func removeOrphanedComponents(model *v3high.Document, refs map[string]bool) int
 func findOperationRefs(model *v3high.Document) map[string]bool {
 	refSet := make(map[string]bool)
 
-	if model.Paths == nil || model.Paths.PathItems == nil {
-		return refSet
-	}
+	// Security schemes are referenced by name from the global and operation-level security requirements
+	collectSecurityRefs(model.Security, refSet)
 
-	// Walk all operations and collect refs
-	for _, pathItem := range model.Paths.PathItems.FromOldest() {
-		// Collect path-level parameters
-		for _, param := range pathItem.Parameters {
-			collectRefFromProxy(param, refSet, model)
+	// Webhooks are generated like regular operations
+	if model.Webhooks != nil {
+		for _, pathItem := range model.Webhooks.FromOldest() {
+			collectPathItemRefs(pathItem, refSet, model)
 		}
+	}
 
-		// Collect operation-level refs
-		for _, op := range pathItem.GetOperations().FromOldest() {
-			// Request body
-			if op.RequestBody != nil {
-				collectRefFromProxy(op.RequestBody, refSet, model)
-			}
-
-			// Parameters
-			for _, param := range op.Parameters {
-				collectRefFromProxy(param, refSet, model)
-			}
-
-			// Responses
-			if op.Responses != nil {
-				if op.Responses.Default != nil {
-					collectRefFromProxy(op.Responses.Default, refSet, model)
-				}
-				for _, resp := range op.Responses.Codes.FromOldest() {
-					collectRefFromProxy(resp, refSet, model)
-				}
-			}
+	// Walk all operations and collect refs
+	if model.Paths != nil && model.Paths.PathItems != nil {
+		for _, pathItem := range model.Paths.PathItems.FromOldest() {
+			collectPathItemRefs(pathItem, refSet, model)
 		}
 	}
 
@@ -319,6 +310,69 @@ func collectSchemaRefs(schema *base.Schema, refSet map[string]bool, model *v3hig
 	}
 }
 
+// collectPathItemRefs collects refs from the parameters and operations of a path item,
+// including the path items of operation callbacks.
+func collectPathItemRefs(pathItem *v3high.PathItem, refSet map[string]bool, model *v3high.Document) {
+	if pathItem == nil {
+		return
+	}
+
+	// Collect path-level parameters
+	for _, param := range pathItem.Parameters {
+		collectRefFromProxy(param, refSet, model)
+	}
+
+	// Collect operation-level refs
+	for _, op := range pathItem.GetOperations().FromOldest() {
+		// Security requirements
+		collectSecurityRefs(op.Security, refSet)
+
+		// Request body
+		if op.RequestBody != nil {
+			collectRefFromProxy(op.RequestBody, refSet, model)
+		}
+
+		// Parameters
+		for _, param := range op.Parameters {
+			collectRefFromProxy(param, refSet, model)
+		}
+
+		// Responses
+		if op.Responses != nil {
+			if op.Responses.Default != nil {
+				collectRefFromProxy(op.Responses.Default, refSet, model)
+			}
+			for _, resp := range op.Responses.Codes.FromOldest() {
+				collectRefFromProxy(resp, refSet, model)
+			}
+		}
+
+		// Callbacks
+		if op.Callbacks != nil {
+			for _, callback := range op.Callbacks.FromOldest() {
+				if callback == nil || callback.Expression == nil {
+					continue
+				}
+				for _, cbPathItem := range callback.Expression.FromOldest() {
+					collectPathItemRefs(cbPathItem, refSet, model)
+				}
+			}
+		}
+	}
+}
+
+// collectSecurityRefs adds a security scheme ref for every scheme named in the requirements.
+func collectSecurityRefs(requirements []*base.SecurityRequirement, refSet map[string]bool) {
+	for _, req := range requirements {
+		if req == nil || req.Requirements == nil {
+			continue
+		}
+		for name := range req.Requirements.KeysFromOldest() {
+			refSet[fmt.Sprintf("#/components/securitySchemes/%s", name)] = true
+		}
+	}
+}
+
 func getComponentKeys(component iter.Seq[string]) []string {
 	keys := make([]string, 0)
 	for k := range component {
@@ -194,6 +194,7 @@ func TestPruningUnusedComponents(t *testing.T) {
 	assert.Equal(t, 0, m.Components.RequestBodies.Len())
 	assert.Equal(t, 0, m.Components.Responses.Len())
 	assert.Equal(t, 0, m.Components.Headers.Len())
+	assert.Equal(t, 0, m.Components.SecuritySchemes.Len())
 	assert.Nil(t, m.Components.Examples)
 	assert.Nil(t, m.Components.Links)
 	assert.Nil(t, m.Components.Callbacks)
@@ -472,7 +473,7 @@ func TestPruneExamples(t *testing.T) {
 		assert.NotNil(t, header.Example)
 	})
 
-	t.Run("webhooks removed during pruning", func(t *testing.T) {
+	t.Run("webhooks kept during pruning", func(t *testing.T) {
 		contents, err := os.ReadFile("testdata/webhooks-with-examples.yml")
 		assert.NoError(t, err)
 
@@ -489,7 +490,9 @@ func TestPruneExamples(t *testing.T) {
 		// components/examples should be removed (set to nil)
 		assert.Nil(t, model.Model.Components.Examples)
 
-		// webhooks should be removed
-		assert.Nil(t, model.Model.Webhooks)
+		// webhooks should be kept along with the schemas they reference
+		assert.NotNil(t, model.Model.Webhooks)
+		assert.Equal(t, 1, model.Model.Webhooks.Len())
+		assert.Equal(t, 2, model.Model.Components.Schemas.Len())
 	})
 }
@@ -51,10 +51,15 @@ type GoSchema struct {
 	Discriminator *Discriminator
 	// True if this schema is a struct wrapper around a union (embedded Either or union field)
 	IsUnionWrapper bool
+	// True if exactly one of UnionElements must match (oneOf), otherwise at least one (anyOf)
+	IsOneOf bool
 
 	DefineViaAlias   bool
 	IsPrimitiveAlias bool
 	OpenAPISchema    *base.Schema
+
+	// xmlTags is set when the struct is generated with xml tags, see ParseOptions.XMLTags.
+	xmlTags bool
 }
 
 func (s GoSchema) IsRef() bool {
@@ -249,14 +254,27 @@ func (s GoSchema) createGoStruct(fields []string) string {
 	// Start out with struct {
 	objectParts := []string{"struct {"}
 
+	// The xml name of the schema names the element of the struct
+	if s.xmlTags && s.OpenAPISchema != nil && s.OpenAPISchema.XML != nil && s.OpenAPISchema.XML.Name != "" {
+		name := s.OpenAPISchema.XML.Name
+		if s.OpenAPISchema.XML.Namespace != "" {
+			name = s.OpenAPISchema.XML.Namespace + " " + name
+		}
+		objectParts = append(objectParts, fmt.Sprintf("XMLName xml.Name `json:\"-\" xml:\"%s\"`", name))
+	}
+
 	// Append all the field definitions
 	objectParts = append(objectParts, fields...)
 
 	// Close the struct
 	if s.HasAdditionalProperties {
+		tags := "`json:\"-\"`"
+		if s.xmlTags {
+			tags = "`json:\"-\" xml:\"-\"`"
+		}
 		objectParts = append(
 			objectParts,
-			fmt.Sprintf("AdditionalProperties map[string]%s `json:\"-\"`", additionalPropertiesType(s)),
+			fmt.Sprintf("AdditionalProperties map[string]%s %s", additionalPropertiesType(s), tags),
 		)
 	}
 
@@ -276,6 +294,8 @@ type Discriminator struct {
 
 	// JSON property name that holds the discriminator
 	Property string
+
+	naming *namer
 }
 
 func (d *Discriminator) JSONTag() string {
@@ -283,7 +303,11 @@ func (d *Discriminator) JSONTag() string {
 }
 
 func (d *Discriminator) PropertyName() string {
-	return schemaNameToTypeName(d.Property)
+	naming := d.naming
+	if naming == nil {
+		naming = defaultNamer
+	}
+	return naming.schemaNameToTypeName(d.Property)
 }
 
 func GenerateGoSchema(schemaProxy *base.SchemaProxy, options ParseOptions) (GoSchema, error) {
@@ -355,7 +379,7 @@ func GenerateGoSchema(schemaProxy *base.SchemaProxy, options ParseOptions) (GoSc
 			if options.visited != nil && options.visited[trackingKey] {
 				// We've encountered a circular reference
 				// Return the referenced type name
-				refType, err := refPathToGoType(ref)
+				refType, err := options.namer().refPathToGoType(ref)
 				if err != nil {
 					return GoSchema{}, fmt.Errorf("error turning reference (%s) into a Go type: %s", ref, err)
 				}
@@ -371,7 +395,7 @@ func GenerateGoSchema(schemaProxy *base.SchemaProxy, options ParseOptions) (GoSc
 			// Not a circular reference, just return the type name.
 			// First, try to look up the actual type name from the type tracker by ref.
 			// This is important because the type may have been renamed via x-go-name or due to conflicts.
-			refType, err := refPathToGoType(ref)
+			refType, err := options.namer().refPathToGoType(ref)
 			if err != nil {
 				return GoSchema{}, fmt.Errorf("error turning reference (%s) into a Go type: %s", schemaProxy.GetReference(), err)
 			}
@@ -471,7 +495,7 @@ func GenerateGoSchema(schemaProxy *base.SchemaProxy, options ParseOptions) (GoSc
 			}
 		}
 		// Fall back to generating a type name from the path
-		typeName := pathToTypeName(options.path)
+		typeName := options.namer().pathToTypeName(options.path)
 		return GoSchema{
 			GoType:         typeName,
 			DefineViaAlias: true,
@@ -638,7 +662,7 @@ func GenerateGoSchema(schemaProxy *base.SchemaProxy, options ParseOptions) (GoSc
 	// but referenced from multiple places
 	if ref != "" && !isStandardComponentReference(ref) {
 		// Generate a type name from the reference path
-		refType, err := refPathToGoType(ref)
+		refType, err := options.namer().refPathToGoType(ref)
 		if err != nil {
 			return GoSchema{}, fmt.Errorf("error turning reference (%s) into a Go type: %w", ref, err)
 		}
@@ -793,7 +817,7 @@ func replaceInlineTypes(src GoSchema, options ParseOptions) (GoSchema, string) {
 		return src, ""
 	}
 
-	baseName := pathToTypeName(options.path)
+	baseName := options.namer().pathToTypeName(options.path)
 	name := baseName
 
 	if options.typeTracker.Exists(baseName) {
@@ -840,6 +864,7 @@ func enhanceSchema(src, other GoSchema, options ParseOptions) GoSchema {
 	src.Properties = append(src.Properties, other.Properties...)
 	src.Discriminator = other.Discriminator
 	src.UnionElements = other.UnionElements
+	src.IsOneOf = other.IsOneOf
 	src.AdditionalTypes = append(src.AdditionalTypes, other.AdditionalTypes...)
 
 	srcFields := genFieldsFromProperties(src.Properties, options)
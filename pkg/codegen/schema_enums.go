@@ -73,7 +73,7 @@ func createEnumsSchema(schema *base.Schema, options ParseOptions) (GoSchema, err
 		}
 	}
 
-	sanitizedValues := sanitizeEnumNames(enumNames, enumValues)
+	sanitizedValues := options.namer().sanitizeEnumNames(enumNames, enumValues)
 
 	// If all enum values were filtered out (e.g., all were null),
 	// treat this as a regular type, not an enum
@@ -85,7 +85,7 @@ func createEnumsSchema(schema *base.Schema, options ParseOptions) (GoSchema, err
 	outSchema.EnumValues = make(map[string]string, len(sanitizedValues))
 
 	for k, v := range sanitizedValues {
-		outSchema.EnumValues[schemaNameToTypeName(k)] = v
+		outSchema.EnumValues[options.namer().schemaNameToTypeName(k)] = v
 	}
 
 	// Fix GoType if enum values don't match the declared type
@@ -118,7 +118,7 @@ func createEnumsSchema(schema *base.Schema, options ParseOptions) (GoSchema, err
 				return outSchema, fmt.Errorf("invalid value for %q: %w", extGoTypeName, err)
 			}
 		} else {
-			typeName = schemaNameToTypeName(pathToTypeName(path))
+			typeName = options.namer().schemaNameToTypeName(options.namer().pathToTypeName(path))
 		}
 
 		// Check if a type with the same name already exists.
@@ -143,7 +143,7 @@ func createEnumsSchema(schema *base.Schema, options ParseOptions) (GoSchema, err
 
 // sanitizeEnumNames fixes illegal chars in the enum names
 // and removes duplicates
-func sanitizeEnumNames(enumNames, enumValues []string) map[string]string {
+func (naming *namer) sanitizeEnumNames(enumNames, enumValues []string) map[string]string {
 	dupCheck := make(map[string]int, len(enumValues))
 	deDup := make([][]string, 0, len(enumValues))
 
@@ -170,7 +170,7 @@ func sanitizeEnumNames(enumNames, enumValues []string) map[string]string {
 
 	for _, p := range deDup {
 		n, v := p[0], p[1]
-		sanitized := sanitizeGoIdentity(schemaNameToTypeName(n))
+		sanitized := sanitizeGoIdentity(naming.schemaNameToTypeName(n))
 
 		// If sanitized is empty (all chars were special chars that got stripped),
 		// use "Empty" as the base name. The duplicate handling below will add
@@ -12,8 +12,10 @@ package codegen
 
 import (
 	"fmt"
+	"regexp"
 	"slices"
 	"strings"
+	"unicode"
 
 	"github.com/pb33f/libopenapi/datamodel/high/base"
 )
@@ -32,6 +34,40 @@ func (gi goImport) String() string {
 	return fmt.Sprintf("%q", gi.Path)
 }
 
+// majorVersionPattern matches the major version of a module path, e.g. v2 in example.com/api/v2.
+var majorVersionPattern = regexp.MustCompile(`^v[0-9]+$`)
+
+// importPathPackageName returns a valid name to import the package of the import path with.
+// It's the last element of the path, skipping the major version of the module (/v2 or .v2),
+// without the characters not allowed in identifiers: example.com/my-api/v2 gives myapi.
+func importPathPackageName(importPath string) string {
+	elems := strings.Split(strings.Trim(importPath, "/"), "/")
+	name := elems[len(elems)-1]
+	if len(elems) > 1 && majorVersionPattern.MatchString(name) {
+		name = elems[len(elems)-2]
+	}
+	if base, version, ok := strings.Cut(name, "."); ok && majorVersionPattern.MatchString(version) {
+		name = base
+	}
+
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			return r
+		}
+		return -1
+	}, name)
+
+	switch {
+	case name == "":
+		return "pkg"
+	case unicode.IsDigit([]rune(name)[0]):
+		return "pkg" + name
+	case isGoKeyword(name) || isPredeclaredGoIdentifier(name):
+		return name + "pkg"
+	}
+	return name
+}
+
 // importMap maps external OpenAPI specifications files/urls to external go packages
 type importMap map[string]goImport
 
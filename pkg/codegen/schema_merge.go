@@ -101,7 +101,7 @@ func createFromCombinator(schema *base.Schema, options ParseOptions) (GoSchema,
 		anyOfSchema.GoType = anyOfSchema.createGoStruct(anyOfFields)
 		anyOfSchema.IsUnionWrapper = len(anyOfSchema.UnionElements) > 0
 
-		anyOfName := pathToTypeName(anyOfPath)
+		anyOfName := options.namer().pathToTypeName(anyOfPath)
 		td := TypeDefinition{
 			Name:             anyOfName,
 			Schema:           anyOfSchema,
@@ -137,8 +137,9 @@ func createFromCombinator(schema *base.Schema, options ParseOptions) (GoSchema,
 		oneOfFields := genFieldsFromProperties(oneOfSchema.Properties, options)
 		oneOfSchema.GoType = oneOfSchema.createGoStruct(oneOfFields)
 		oneOfSchema.IsUnionWrapper = len(oneOfSchema.UnionElements) > 0
+		oneOfSchema.IsOneOf = oneOfSchema.IsUnionWrapper
 
-		oneOfName := pathToTypeName(oneOfPath)
+		oneOfName := options.namer().pathToTypeName(oneOfPath)
 		td := TypeDefinition{
 			Name:             oneOfName,
 			Schema:           oneOfSchema,
@@ -359,7 +360,7 @@ func mergeAllOfSchemas(allOf []*base.SchemaProxy, options ParseOptions) (GoSchem
 
 		ref := schemaProxy.GoLow().GetReference()
 		if ref != "" {
-			typeName, err := refPathToGoType(ref)
+			typeName, err := options.namer().refPathToGoType(ref)
 			if err != nil {
 				return GoSchema{}, fmt.Errorf("error converting reference to type name: %w", err)
 			}
@@ -415,7 +416,7 @@ func mergeAllOfSchemas(allOf []*base.SchemaProxy, options ParseOptions) (GoSchem
 			continue
 		}
 
-		fieldName := pathToTypeName(subPath)
+		fieldName := options.namer().pathToTypeName(subPath)
 		out.Properties = append(out.Properties, Property{
 			GoName:      fieldName,
 			Schema:      GoSchema{RefType: fieldName},
@@ -102,7 +102,7 @@ func oapiSchemaToGoType(schema *base.Schema, options ParseOptions) (GoSchema, er
 				preRegisteredTypeName = existingName
 				weRegisteredRef = false
 			} else {
-				preRegisteredTypeName = pathToTypeName(append(path, "Item"))
+				preRegisteredTypeName = options.namer().pathToTypeName(append(path, "Item"))
 				if options.typeTracker.Exists(preRegisteredTypeName) {
 					preRegisteredTypeName = options.typeTracker.generateUniqueName(preRegisteredTypeName)
 				}
@@ -137,7 +137,7 @@ func oapiSchemaToGoType(schema *base.Schema, options ParseOptions) (GoSchema, er
 			// Use the pre-registered type name if available, otherwise generate a new one.
 			typeName := preRegisteredTypeName
 			if typeName == "" {
-				typeName = pathToTypeName(append(path, "Item"))
+				typeName = options.namer().pathToTypeName(append(path, "Item"))
 				// Check if the type name already exists.
 				// If it does, generate a unique name to avoid conflicts and overwrites.
 				// This handles cases like allOf with duplicate property names where each
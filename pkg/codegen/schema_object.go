@@ -37,6 +37,7 @@ func createObjectSchema(schema *base.Schema, options ParseOptions) (GoSchema, er
 			hasNilType:   hasNilType,
 			specLocation: options.specLocation,
 		}),
+		xmlTags: options.XMLTags,
 	}
 
 	schemaExtensions := make(map[string]any)
@@ -140,7 +141,7 @@ func createObjectSchema(schema *base.Schema, options ParseOptions) (GoSchema, er
 					// but are not a pre-defined type, we need to define a type
 					// for them, which will be based on the field names we followed
 					// to get to the type.
-					typeName := pathToTypeName(append(propertyPath, "AdditionalProperties"))
+					typeName := options.namer().pathToTypeName(append(propertyPath, "AdditionalProperties"))
 
 					// Use parent's SpecLocation if set, otherwise default to Schema or Union
 					var specLocation = options.specLocation
@@ -166,11 +167,13 @@ func createObjectSchema(schema *base.Schema, options ParseOptions) (GoSchema, er
 				extensions := make(map[string]any)
 				deprecated := false
 				var sensitiveData *runtime.SensitiveDataConfig
+				var xmlObject *base.XML
 
 				if p.Schema() != nil {
 					s := p.Schema()
 					description = s.Description
 					extensions = extractExtensions(s.Extensions)
+					xmlObject = s.XML
 					if s.Deprecated != nil {
 						deprecated = *s.Deprecated
 					}
@@ -186,7 +189,7 @@ func createObjectSchema(schema *base.Schema, options ParseOptions) (GoSchema, er
 				pSchema, _ = replaceInlineTypes(pSchema, opts)
 
 				// Generate the Go field name and handle conflicts
-				baseGoName := createPropertyGoFieldName(pName, extensions)
+				baseGoName := options.namer().createPropertyGoFieldName(pName, extensions)
 				goName := baseGoName
 				if count, exists := goFieldNames[baseGoName]; exists {
 					// Conflict detected - append a number
@@ -201,7 +204,7 @@ func createObjectSchema(schema *base.Schema, options ParseOptions) (GoSchema, er
 				// Use the first element of the path as the parent type name
 				parentType := ""
 				if len(path) > 0 {
-					parentType = pathToTypeName(path[:1])
+					parentType = options.namer().pathToTypeName(path[:1])
 				}
 
 				prop := Property{
@@ -214,6 +217,7 @@ func createObjectSchema(schema *base.Schema, options ParseOptions) (GoSchema, er
 					Constraints:   constraints,
 					SensitiveData: sensitiveData,
 					ParentType:    parentType,
+					XML:           xmlObject,
 				}
 				outSchema.Properties = append(outSchema.Properties, prop)
 				if len(pSchema.AdditionalTypes) > 0 {
@@ -297,7 +301,7 @@ func enhanceSchemaWithAdditionalProperties(out GoSchema, schema *base.Schema, op
 				preRegisteredTypeName = existingName
 				weRegisteredRef = false
 			} else {
-				preRegisteredTypeName = pathToTypeName(append(path, "AdditionalProperties"))
+				preRegisteredTypeName = options.namer().pathToTypeName(append(path, "AdditionalProperties"))
 				if options.typeTracker.Exists(preRegisteredTypeName) {
 					preRegisteredTypeName = options.typeTracker.generateUniqueName(preRegisteredTypeName)
 				}
@@ -327,7 +331,7 @@ func enhanceSchemaWithAdditionalProperties(out GoSchema, schema *base.Schema, op
 			// Use the pre-registered type name if available, otherwise generate a new one.
 			typeName := preRegisteredTypeName
 			if typeName == "" {
-				typeName = pathToTypeName(append(path, "AdditionalProperties"))
+				typeName = options.namer().pathToTypeName(append(path, "AdditionalProperties"))
 			}
 
 			typeDef := TypeDefinition{
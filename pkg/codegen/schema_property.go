@@ -15,6 +15,7 @@ import (
 	"slices"
 	"strings"
 
+	"github.com/pb33f/libopenapi/datamodel/high/base"
 	"github.com/yorunikakeru4/oapi-codegen-dd/v3/pkg/runtime"
 )
 
@@ -27,7 +28,8 @@ type Property struct {
 	Deprecated    bool
 	Constraints   Constraints
 	SensitiveData *runtime.SensitiveDataConfig
-	ParentType    string // Name of the parent type (for detecting recursive references)
+	ParentType    string    // Name of the parent type (for detecting recursive references)
+	XML           *base.XML // The OpenAPI xml object of the property schema
 }
 
 func (p Property) IsEqual(other Property) bool {
@@ -162,7 +164,7 @@ func (p Property) needsCustomValidation() bool {
 	return true
 }
 
-func createPropertyGoFieldName(jsonName string, extensions map[string]any) string {
+func (n *namer) createPropertyGoFieldName(jsonName string, extensions map[string]any) string {
 	goFieldName := jsonName
 	if extension, ok := extensions[extGoName]; ok {
 		if extGoFieldName, err := parseString(extension); err == nil {
@@ -186,7 +188,7 @@ func createPropertyGoFieldName(jsonName string, extensions map[string]any) strin
 	}
 
 	// "Validate" conflicts with the Validate() method that we generate for validation
-	typeName := schemaNameToTypeName(goFieldName)
+	typeName := n.schemaNameToTypeName(goFieldName)
 	if typeName == "Validate" {
 		return "ValidateData"
 	}
@@ -298,6 +300,10 @@ func genFieldsFromProperties(props []Property, options ParseOptions) []string {
 			}
 		}
 
+		if options.XMLTags {
+			fieldTags["xml"] = xmlFieldTag(p, omitEmpty)
+		}
+
 		// Support x-oapi-codegen-extra-tags
 		if extension, ok := p.Extensions[extPropExtraTags]; ok {
 			if tags, err := extExtraTags(extension); err == nil {
@@ -346,6 +352,48 @@ func genFieldsFromProperties(props []Property, options ParseOptions) []string {
 	return fields
 }
 
+// xmlFieldTag returns the xml struct tag of a property, following its OpenAPI xml object:
+// name renames the element, attribute makes it an attribute, wrapped nests array items into the element,
+// and namespace qualifies it. Prefixes aren't kept by encoding/xml, elements are matched by namespace.
+func xmlFieldTag(p Property, omitEmpty bool) string {
+	if p.JsonFieldName == "" || strings.HasPrefix(p.Schema.TypeDecl(), "map[") {
+		return "-"
+	}
+
+	name := p.JsonFieldName
+	x := p.XML
+	if x != nil && x.Name != "" {
+		name = x.Name
+	}
+
+	if p.Schema.ArrayType != nil {
+		itemName := ""
+		if items := p.Schema.ArrayType.OpenAPISchema; items != nil && items.XML != nil {
+			itemName = items.XML.Name
+		}
+		switch {
+		case x != nil && x.Wrapped && itemName != "":
+			name += ">" + itemName
+		case x != nil && x.Wrapped:
+			name += ">" + p.JsonFieldName
+		case itemName != "":
+			name = itemName
+		}
+	}
+
+	tag := name
+	if x != nil && x.Namespace != "" {
+		tag = x.Namespace + " " + name
+	}
+	if x != nil && x.Attribute {
+		tag += ",attr"
+	}
+	if omitEmpty {
+		tag += ",omitempty"
+	}
+	return tag
+}
+
 // extractPropertyFieldValue extracts a field value from a Property based on the field name.
 // Supported field names:
 // - "description": returns the property description
@@ -49,6 +49,7 @@ func generateUnion(elements []*base.SchemaProxy, discriminator *base.Discriminat
 		outSchema.Discriminator = &Discriminator{
 			Property: discriminator.PropertyName,
 			Mapping:  make(map[string]string),
+			naming:   options.namer(),
 		}
 	}
 
@@ -137,7 +138,7 @@ func generateUnion(elements []*base.SchemaProxy, discriminator *base.Discriminat
 
 		// define new types only for non-primitive types
 		if ref == "" && !isPrimitiveType(elementSchema.GoType) {
-			elementName := pathToTypeName(elementPath)
+			elementName := options.namer().pathToTypeName(elementPath)
 			if elementSchema.TypeDecl() != elementName {
 				td := TypeDefinition{
 					Schema:         elementSchema,
@@ -155,7 +156,7 @@ func generateUnion(elements []*base.SchemaProxy, discriminator *base.Discriminat
 			// Handle path-based references (not component refs)
 			// For path-based references to inline schemas, we need to create type definitions
 			if !isStandardComponentReference(ref) && strings.HasPrefix(elementSchema.GoType, "struct") {
-				elementName := pathToTypeName(elementPath)
+				elementName := options.namer().pathToTypeName(elementPath)
 				// Check if a type definition already exists
 				typeExists := false
 				for _, at := range elementSchema.AdditionalTypes {
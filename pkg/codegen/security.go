@@ -0,0 +1,186 @@
+// Copyright 2025 DoorDash, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package codegen
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3high "github.com/pb33f/libopenapi/datamodel/high/v3"
+)
+
+// Security scheme types as defined by the OpenAPI specification.
+const (
+	SecuritySchemeTypeAPIKey        = "apiKey"
+	SecuritySchemeTypeHTTP          = "http"
+	SecuritySchemeTypeOAuth2        = "oauth2"
+	SecuritySchemeTypeOpenIDConnect = "openIdConnect"
+	SecuritySchemeTypeMutualTLS     = "mutualTLS"
+)
+
+// SecuritySchemeDefinition describes a security scheme from components.securitySchemes.
+// Name is the key of the scheme in the spec, GoName is its Go identifier.
+// In and ParamName are only set for apiKey schemes, Scheme and BearerFormat for http schemes.
+// Scopes lists all scopes declared by the OAuth2 flows.
+type SecuritySchemeDefinition struct {
+	Name         string
+	GoName       string
+	Type         string
+	Description  string
+	Scheme       string
+	BearerFormat string
+	In           string
+	ParamName    string
+	Scopes       []string
+}
+
+// IsBearer returns true if the credentials are sent as a bearer token.
+func (s SecuritySchemeDefinition) IsBearer() bool {
+	switch s.Type {
+	case SecuritySchemeTypeHTTP:
+		return strings.EqualFold(s.Scheme, "bearer")
+	case SecuritySchemeTypeOAuth2, SecuritySchemeTypeOpenIDConnect:
+		return true
+	}
+	return false
+}
+
+// IsBasic returns true if the scheme is HTTP basic authentication.
+func (s SecuritySchemeDefinition) IsBasic() bool {
+	return s.Type == SecuritySchemeTypeHTTP && strings.EqualFold(s.Scheme, "basic")
+}
+
+// IsAPIKey returns true if the scheme is an API key.
+func (s SecuritySchemeDefinition) IsAPIKey() bool {
+	return s.Type == SecuritySchemeTypeAPIKey
+}
+
+// IsTokenSource returns true if the client obtains tokens from a runtime.TokenSource.
+func (s SecuritySchemeDefinition) IsTokenSource() bool {
+	return s.Type == SecuritySchemeTypeOAuth2 || s.Type == SecuritySchemeTypeOpenIDConnect
+}
+
+// SecurityRequirement is a single entry of an operation's security list.
+// All schemes of a requirement must be satisfied together. An empty requirement allows anonymous access.
+type SecurityRequirement struct {
+	Schemes []SecurityRequirementScheme
+}
+
+// SecurityRequirementScheme references a security scheme along with the scopes required from it.
+type SecurityRequirementScheme struct {
+	Name   string
+	Scopes []string
+}
+
+// collectSecuritySchemes turns components.securitySchemes into a sorted list of definitions.
+func collectSecuritySchemes(model *v3high.Document, naming *namer) ([]SecuritySchemeDefinition, error) {
+	if model.Components == nil || model.Components.SecuritySchemes == nil {
+		return nil, nil
+	}
+
+	var res []SecuritySchemeDefinition
+	for name, scheme := range model.Components.SecuritySchemes.FromOldest() {
+		if scheme == nil {
+			continue
+		}
+
+		def := SecuritySchemeDefinition{
+			Name:         name,
+			GoName:       naming.schemaNameToTypeName(name),
+			Type:         scheme.Type,
+			Description:  scheme.Description,
+			Scheme:       scheme.Scheme,
+			BearerFormat: scheme.BearerFormat,
+		}
+
+		switch scheme.Type {
+		case SecuritySchemeTypeAPIKey:
+			switch scheme.In {
+			case "header", "query", "cookie":
+			default:
+				return nil, fmt.Errorf("security scheme %q: unsupported apiKey location %q", name, scheme.In)
+			}
+			if scheme.Name == "" {
+				return nil, fmt.Errorf("security scheme %q: apiKey name is required", name)
+			}
+			def.In = scheme.In
+			def.ParamName = scheme.Name
+		case SecuritySchemeTypeOAuth2:
+			def.Scopes = collectOAuthScopes(scheme.Flows)
+		}
+
+		res = append(res, def)
+	}
+
+	slices.SortFunc(res, func(a, b SecuritySchemeDefinition) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return res, nil
+}
+
+func collectOAuthScopes(flows *v3high.OAuthFlows) []string {
+	if flows == nil {
+		return nil
+	}
+
+	seen := map[string]bool{}
+	var scopes []string
+	for _, flow := range []*v3high.OAuthFlow{flows.Implicit, flows.Password, flows.ClientCredentials, flows.AuthorizationCode} {
+		if flow == nil || flow.Scopes == nil {
+			continue
+		}
+		for scope := range flow.Scopes.KeysFromOldest() {
+			if !seen[scope] {
+				seen[scope] = true
+				scopes = append(scopes, scope)
+			}
+		}
+	}
+	slices.Sort(scopes)
+
+	return scopes
+}
+
+// describeSecurityRequirements returns the security requirements of an operation.
+// Operation-level security overrides the global one.
+// A nil result means no security is required, which also covers an explicit empty list.
+func describeSecurityRequirements(operation *v3high.Operation, global []*base.SecurityRequirement) []SecurityRequirement {
+	requirements := operation.Security
+	if requirements == nil {
+		requirements = global
+	}
+
+	var res []SecurityRequirement
+	for _, req := range requirements {
+		res = append(res, newSecurityRequirement(req))
+	}
+
+	return res
+}
+
+func newSecurityRequirement(req *base.SecurityRequirement) SecurityRequirement {
+	var res SecurityRequirement
+	if req == nil || req.Requirements == nil {
+		return res
+	}
+
+	for name, scopes := range req.Requirements.FromOldest() {
+		res.Schemes = append(res.Schemes, SecurityRequirementScheme{
+			Name:   name,
+			Scopes: scopes,
+		})
+	}
+
+	return res
+}
@@ -0,0 +1,142 @@
+// Copyright 2025 DoorDash, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package codegen
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	v3high "github.com/pb33f/libopenapi/datamodel/high/v3"
+)
+
+var serverVariablePattern = regexp.MustCompile(`\{([^}]+)}`)
+
+// ServerDefinition describes an entry of the top-level servers list.
+// GoName is derived from the x-go-name extension, the name (OpenAPI 3.2), the description or the URL, in this order.
+// URL is the server URL as declared, with {variable} placeholders.
+type ServerDefinition struct {
+	GoName      string
+	URL         string
+	Description string
+	Variables   []ServerVariableDefinition
+}
+
+// ServerVariableDefinition describes a variable substituted in a server URL.
+// ParamName is the name of the Go function parameter, Enum lists the allowed values, if restricted.
+type ServerVariableDefinition struct {
+	Name        string
+	ParamName   string
+	Default     string
+	Description string
+	Enum        []string
+}
+
+// DefaultURL returns the server URL with each variable replaced by its default value.
+func (s ServerDefinition) DefaultURL() string {
+	res := s.URL
+	for _, v := range s.Variables {
+		res = strings.ReplaceAll(res, "{"+v.Name+"}", v.Default)
+	}
+	return res
+}
+
+// collectServers turns the top-level servers into definitions with unique Go names.
+func collectServers(model *v3high.Document, naming *namer) ([]ServerDefinition, error) {
+	var res []ServerDefinition
+	seen := map[string]int{}
+	for i, server := range model.Servers {
+		if server == nil || server.URL == "" {
+			continue
+		}
+
+		def := ServerDefinition{
+			GoName:      naming.serverGoName(server, i),
+			URL:         server.URL,
+			Description: server.Description,
+		}
+		if n := seen[def.GoName]; n > 0 {
+			seen[def.GoName]++
+			def.GoName = fmt.Sprintf("%s%d", def.GoName, n+1)
+		} else {
+			seen[def.GoName] = 1
+		}
+
+		// Variables in the order they appear in the URL
+		for _, match := range serverVariablePattern.FindAllStringSubmatch(server.URL, -1) {
+			name := match[1]
+			if slices.ContainsFunc(def.Variables, func(v ServerVariableDefinition) bool { return v.Name == name }) {
+				continue
+			}
+
+			var variable *v3high.ServerVariable
+			if server.Variables != nil {
+				variable = server.Variables.GetOrZero(name)
+			}
+			if variable == nil {
+				return nil, fmt.Errorf("server %q: variable %q is not declared", server.URL, name)
+			}
+			if len(variable.Enum) > 0 && !slices.Contains(variable.Enum, variable.Default) {
+				return nil, fmt.Errorf("server %q: default %q of variable %q is not in its enum", server.URL, variable.Default, name)
+			}
+
+			paramName := lowercaseFirstCharacter(naming.schemaNameToTypeName(name))
+			if isGoKeyword(paramName) || isPredeclaredGoIdentifier(paramName) {
+				paramName += "Value"
+			}
+			def.Variables = append(def.Variables, ServerVariableDefinition{
+				Name:        name,
+				ParamName:   paramName,
+				Default:     variable.Default,
+				Description: variable.Description,
+				Enum:        variable.Enum,
+			})
+		}
+
+		res = append(res, def)
+	}
+
+	return res, nil
+}
+
+// serverGoName names a server after its x-go-name extension, its name, its description or its URL.
+// A trailing "Server" is dropped from the description, as the constants are prefixed with it.
+func (n *namer) serverGoName(server *v3high.Server, index int) string {
+	if server.Extensions != nil {
+		if ext := server.Extensions.GetOrZero(extGoName); ext != nil && ext.Value != "" {
+			return n.schemaNameToTypeName(ext.Value)
+		}
+	}
+
+	if server.Name != "" {
+		return n.schemaNameToTypeName(server.Name)
+	}
+
+	if name := n.normalize(server.Description); name != "" {
+		if trimmed := strings.TrimSuffix(name, "Server"); trimmed != "" {
+			name = trimmed
+		}
+		return typeNamePrefix(name) + name
+	}
+
+	// Host and path of the URL, without the variables
+	raw := serverVariablePattern.ReplaceAllString(server.URL, "")
+	if u, err := url.Parse(raw); err == nil {
+		raw = u.Host + u.Path
+	}
+	if name := n.normalize(raw); name != "" {
+		return typeNamePrefix(name) + name
+	}
+
+	return fmt.Sprintf("Default%d", index+1)
+}
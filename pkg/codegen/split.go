@@ -0,0 +1,324 @@
+// Copyright 2025 DoorDash, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package codegen
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"maps"
+	"path"
+	"slices"
+	"strings"
+)
+
+// untaggedPackageName is the sub-package of the operations without tags.
+const untaggedPackageName = "untagged"
+
+// TplSharedTypesContext is the context passed to templates to alias the identifiers
+// of the generated package used by one of its sub-packages.
+type TplSharedTypesContext struct {
+	Imports    []string
+	Config     Configuration
+	WithHeader bool
+
+	// Package is the name the generated package is imported with.
+	Package   string
+	Types     []string
+	Constants []string
+	Variables []string
+}
+
+// operationGroup holds the operations generated in a sub-package.
+type operationGroup struct {
+	packageName string
+	operations  []OperationDefinition
+	webhooks    []OperationDefinition
+}
+
+// headerTypes returns the names of the typed response headers of the operations.
+// They're declared in the sub-package, as the handler defines methods on them.
+func (g *operationGroup) headerTypes() map[string]bool {
+	res := make(map[string]bool)
+	for _, op := range slices.Concat(g.operations, g.webhooks) {
+		if op.Response.Success != nil && op.Response.Success.TypedHeaders != nil {
+			res[op.Response.Success.TypedHeaders.Name] = true
+		}
+	}
+	return res
+}
+
+// validateSplitOutput returns an error if the output can't be split with the configuration.
+func validateSplitOutput(cfg Configuration) error {
+	if cfg.Output.SplitBy != OutputSplitByTag {
+		return fmt.Errorf("%w: %q", ErrOutputSplitByUnsupported, cfg.Output.SplitBy)
+	}
+	if cfg.Output.UseSingleFile {
+		return ErrOutputSplitSingleFile
+	}
+	if cfg.Output.ImportPath == "" {
+		return ErrOutputSplitImportPathRequired
+	}
+	if cfg.Generate.Handler != nil && cfg.Generate.Handler.Server != nil {
+		return ErrOutputSplitServer
+	}
+	return nil
+}
+
+// parseSplitByTag generates the types in the package and the operations of every tag in a sub-package.
+// Sub-package files are keyed by the sub-package name, e.g. "pets/client".
+// The sub-packages alias the types they use, so the generated operations code is the same as in a single package.
+func (p *Parser) parseSplitByTag() (GeneratedCode, error) {
+	if err := validateSplitOutput(p.cfg); err != nil {
+		return nil, err
+	}
+
+	groups := groupOperationsByTag(p.ctx.Operations, p.ctx.Webhooks)
+	headerTypes := make(map[string]bool)
+	for _, group := range groups {
+		maps.Copy(headerTypes, group.headerTypes())
+	}
+
+	sharedCtx := *p.ctx
+	sharedCtx.Operations = nil
+	sharedCtx.Webhooks = nil
+	sharedCtx.TypeDefinitions = maps.Clone(p.ctx.TypeDefinitions)
+	sharedCtx.TypeDefinitions[SpecLocationHeader] = slices.DeleteFunc(slices.Clone(p.ctx.TypeDefinitions[SpecLocationHeader]), func(td TypeDefinition) bool {
+		return headerTypes[td.Name]
+	})
+	shared := &Parser{tpl: p.tpl, ctx: &sharedCtx, cfg: p.cfg.withoutSplit()}
+	res, err := shared.Parse()
+	if err != nil {
+		return nil, err
+	}
+
+	declared, err := collectDeclarations(res)
+	if err != nil {
+		return nil, fmt.Errorf("error collecting declarations of package %s: %w", p.cfg.PackageName, err)
+	}
+
+	for _, group := range groups {
+		groupHeaderTypes := group.headerTypes()
+		groupCtx := *p.ctx
+		groupCtx.Operations = group.operations
+		groupCtx.Webhooks = group.webhooks
+		groupCtx.Enums = nil
+		groupCtx.UnionTypes = nil
+		groupCtx.TypeDefinitions = map[SpecLocation][]TypeDefinition{
+			SpecLocationHeader: slices.DeleteFunc(slices.Clone(p.ctx.TypeDefinitions[SpecLocationHeader]), func(td TypeDefinition) bool {
+				return !groupHeaderTypes[td.Name]
+			}),
+		}
+		groupCfg := p.cfg.forSubPackage(group.packageName, len(groupHeaderTypes) > 0)
+		sub := &Parser{tpl: p.tpl, ctx: &groupCtx, cfg: groupCfg}
+
+		out, err := sub.Parse()
+		if err != nil {
+			return nil, fmt.Errorf("error generating package %s: %w", group.packageName, err)
+		}
+
+		sharedTypes, err := sub.parseSharedTypes(out, declared)
+		if err != nil {
+			return nil, fmt.Errorf("error generating shared types of package %s: %w", group.packageName, err)
+		}
+		if sharedTypes != "" {
+			out["shared_types"] = sharedTypes
+		}
+
+		for name, code := range out {
+			if IsScaffoldFile(name) {
+				name = scaffoldPrefix + path.Join(p.cfg.Output.Directory, p.cfg.PackageName, group.packageName, ScaffoldFileName(name))
+			} else {
+				name = group.packageName + "/" + name
+			}
+			res[name] = code
+		}
+	}
+
+	return res, nil
+}
+
+// parseSharedTypes generates the aliases of the identifiers declared in the generated package
+// and used by the sub-package code.
+func (p *Parser) parseSharedTypes(code GeneratedCode, declared map[string]token.Token) (string, error) {
+	used := make(map[string]bool)
+	local := make(map[string]bool)
+	for name, src := range code {
+		file, err := parser.ParseFile(token.NewFileSet(), name+".go", src, 0)
+		if err != nil {
+			return "", err
+		}
+		for _, ident := range file.Unresolved {
+			used[ident.Name] = true
+		}
+		for name := range fileDeclarations(file) {
+			local[name] = true
+		}
+	}
+
+	ctx := TplSharedTypesContext{
+		Imports:    []string{fmt.Sprintf("%s %q", p.sharedPackageAlias(), p.cfg.Output.ImportPath)},
+		Config:     p.cfg,
+		WithHeader: true,
+		Package:    p.sharedPackageAlias(),
+	}
+	for name := range used {
+		if local[name] {
+			continue
+		}
+		switch declared[name] {
+		case token.TYPE:
+			ctx.Types = append(ctx.Types, name)
+		case token.CONST:
+			ctx.Constants = append(ctx.Constants, name)
+		case token.VAR, token.FUNC:
+			ctx.Variables = append(ctx.Variables, name)
+		}
+	}
+	if len(ctx.Types)+len(ctx.Constants)+len(ctx.Variables) == 0 {
+		return "", nil
+	}
+	slices.Sort(ctx.Types)
+	slices.Sort(ctx.Constants)
+	slices.Sort(ctx.Variables)
+
+	out, err := p.ParseTemplates([]string{"shared-types.tmpl"}, ctx)
+	if err != nil {
+		return "", err
+	}
+	return FormatCode(out)
+}
+
+// sharedPackageAlias returns the name the sub-package imports the generated package with.
+func (p *Parser) sharedPackageAlias() string {
+	return importPathPackageName(p.cfg.Output.ImportPath)
+}
+
+// collectDeclarations returns the exported identifiers declared in the generated code
+// along with their kind.
+func collectDeclarations(code GeneratedCode) (map[string]token.Token, error) {
+	res := make(map[string]token.Token)
+	for name, src := range code {
+		if IsScaffoldFile(name) {
+			continue
+		}
+		file, err := parser.ParseFile(token.NewFileSet(), name+".go", src, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		for ident, kind := range fileDeclarations(file) {
+			if token.IsExported(ident) {
+				res[ident] = kind
+			}
+		}
+	}
+	return res, nil
+}
+
+// fileDeclarations returns the package level identifiers declared in the file, methods excluded.
+func fileDeclarations(file *ast.File) map[string]token.Token {
+	res := make(map[string]token.Token)
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			if d.Recv == nil {
+				res[d.Name.Name] = token.FUNC
+			}
+		case *ast.GenDecl:
+			for _, spec := range d.Specs {
+				switch s := spec.(type) {
+				case *ast.TypeSpec:
+					res[s.Name.Name] = token.TYPE
+				case *ast.ValueSpec:
+					for _, ident := range s.Names {
+						res[ident.Name] = d.Tok
+					}
+				}
+			}
+		}
+	}
+	return res
+}
+
+// groupOperationsByTag groups the operations by the sub-package of their first tag,
+// in the order the sub-packages first appear.
+func groupOperationsByTag(operations, webhooks []OperationDefinition) []*operationGroup {
+	var groups []*operationGroup
+	byName := make(map[string]*operationGroup)
+	group := func(op OperationDefinition) *operationGroup {
+		name := untaggedPackageName
+		if len(op.Tags) > 0 {
+			name = tagToPackageName(op.Tags[0])
+		}
+		if g, ok := byName[name]; ok {
+			return g
+		}
+		g := &operationGroup{packageName: name}
+		byName[name] = g
+		groups = append(groups, g)
+		return g
+	}
+
+	for _, op := range operations {
+		g := group(op)
+		g.operations = append(g.operations, op)
+	}
+	for _, op := range webhooks {
+		g := group(op)
+		g.webhooks = append(g.webhooks, op)
+	}
+	return groups
+}
+
+// tagToPackageName converts a tag to a Go package name, e.g. "Pet Store" -> "petstore".
+func tagToPackageName(tag string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(tag) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			sb.WriteRune(r)
+		}
+	}
+	name := sb.String()
+	if name == "" {
+		return untaggedPackageName
+	}
+	if name[0] >= '0' && name[0] <= '9' || token.IsKeyword(name) {
+		name = "tag" + name
+	}
+	return name
+}
+
+// withoutSplit returns a copy of the configuration generating a single package.
+func (o Configuration) withoutSplit() Configuration {
+	output := *o.Output
+	output.SplitBy = ""
+	o.Output = &output
+	return o
+}
+
+// forSubPackage returns a copy of the configuration generating the operations of a sub-package.
+// Types are aliased from the generated package, models are only generated for the typed response headers.
+func (o Configuration) forSubPackage(packageName string, models bool) Configuration {
+	o = o.withoutSplit()
+	o.PackageName = packageName
+
+	generate := *o.Generate
+	generate.Models = &models
+	generate.EmbeddedSpec = false
+	if generate.Handler != nil {
+		handler := *generate.Handler
+		handler.Output = nil
+		generate.Handler = &handler
+	}
+	o.Generate = &generate
+	return o
+}
@@ -0,0 +1,124 @@
+// Copyright 2025 DoorDash, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package codegen
+
+import (
+	"go/format"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTagToPackageName(t *testing.T) {
+	tests := map[string]string{
+		"pets":         "pets",
+		"Store Orders": "storeorders",
+		"user-profile": "userprofile",
+		"3D Models":    "tag3dmodels",
+		"default":      "tagdefault",
+		"---":          "untagged",
+	}
+	for tag, expected := range tests {
+		t.Run(tag, func(t *testing.T) {
+			assert.Equal(t, expected, tagToPackageName(tag))
+		})
+	}
+}
+
+func TestSplitByTag(t *testing.T) {
+	newConfig := func() Configuration {
+		return Configuration{
+			PackageName: "api",
+			Output: &Output{
+				Directory:  "gen",
+				SplitBy:    OutputSplitByTag,
+				ImportPath: "example.com/app/gen/api",
+			},
+			Generate: &GenerateOptions{
+				Client: true,
+				Handler: &HandlerOptions{
+					Kind: HandlerKindChi,
+				},
+			},
+		}
+	}
+	spec := []byte(readTestdata(t, "split-by-tag.yml"))
+
+	t.Run("packages", func(t *testing.T) {
+		codes, err := Generate(spec, newConfig())
+		require.NoError(t, err)
+
+		for _, name := range []string{"types", "pets/client", "pets/adapter", "storeorders/client", "storeorders/webhook_sender", "untagged/client"} {
+			assert.Contains(t, codes, name)
+		}
+		assert.NotContains(t, codes, "client")
+		assert.Contains(t, codes, "scaffold:gen/api/pets/service")
+
+		// component schemas stay in the generated package
+		assert.Contains(t, codes["types"], "package api")
+		assert.Contains(t, codes["types"], "type Pet struct {")
+
+		// each tag gets its own client and service interface
+		assert.Contains(t, codes["pets/client"], "package pets")
+		assert.Contains(t, codes["pets/client"], "ListPets(ctx context.Context")
+		assert.NotContains(t, codes["pets/client"], "PlaceOrder(")
+		assert.Contains(t, codes["storeorders/adapter"], "PlaceOrder(ctx context.Context")
+		assert.NotContains(t, codes["storeorders/adapter"], "ListPets(")
+
+		// the types used by a tag are aliased from the generated package
+		shared := codes["pets/shared_types"]
+		assert.Contains(t, shared, `api "example.com/app/gen/api"`)
+		assert.Regexp(t, `PetStatus\s+= api.PetStatus`, shared)
+		assert.NotContains(t, shared, "PlaceOrderBody")
+
+		// typed response headers are declared in the tag package, the handler defines methods on them
+		assert.Contains(t, codes["pets/headers"], "type ListPetsResponseHeaders struct {")
+		assert.NotContains(t, codes["headers"], "ListPetsResponseHeaders")
+
+		// untagged operations don't use any shared type
+		assert.NotContains(t, codes, "untagged/shared_types")
+	})
+
+	t.Run("import path is not a valid package name", func(t *testing.T) {
+		cfg := newConfig()
+		cfg.Output.ImportPath = "example.com/my-api/v3"
+		codes, err := Generate(spec, cfg)
+		require.NoError(t, err)
+
+		shared := codes["pets/shared_types"]
+		assert.Contains(t, shared, `myapi "example.com/my-api/v3"`)
+		assert.Regexp(t, `PetStatus\s+= myapi.PetStatus`, shared)
+		_, err = format.Source([]byte(shared))
+		require.NoError(t, err)
+	})
+
+	t.Run("import path is required", func(t *testing.T) {
+		cfg := newConfig()
+		cfg.Output.ImportPath = ""
+		_, err := Generate(spec, cfg)
+		require.ErrorIs(t, err, ErrOutputSplitImportPathRequired)
+	})
+
+	t.Run("single file is not supported", func(t *testing.T) {
+		cfg := newConfig()
+		cfg.Output.UseSingleFile = true
+		_, err := Generate(spec, cfg)
+		require.ErrorIs(t, err, ErrOutputSplitSingleFile)
+	})
+
+	t.Run("unsupported value", func(t *testing.T) {
+		cfg := newConfig()
+		cfg.Output.SplitBy = "path"
+		_, err := Generate(spec, cfg)
+		require.ErrorIs(t, err, ErrOutputSplitByUnsupported)
+	})
+}
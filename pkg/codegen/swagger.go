@@ -0,0 +1,833 @@
+// Copyright 2025 DoorDash, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strings"
+
+	"go.yaml.in/yaml/v4"
+)
+
+// swaggerConvertedVersion is the OpenAPI version Swagger 2.0 documents are converted to.
+const swaggerConvertedVersion = "3.0.3"
+
+var swaggerOperationMethods = []string{"get", "put", "post", "delete", "options", "head", "patch"}
+
+// swaggerParamSchemaKeys are the parameter, header and items fields that move into the schema in OpenAPI 3.
+var swaggerParamSchemaKeys = []string{
+	"type", "format", "items", "default", "maximum", "exclusiveMaximum", "minimum", "exclusiveMinimum",
+	"maxLength", "minLength", "pattern", "maxItems", "minItems", "uniqueItems", "enum", "multipleOf",
+}
+
+// ConvertSwagger2 converts a Swagger 2.0 document into an equivalent OpenAPI 3 document.
+// Documents of other versions are returned unchanged.
+// The returned warnings describe constructs that could not be converted, they are left out of the result.
+func ConvertSwagger2(contents []byte) ([]byte, []string, error) {
+	if !bytes.Contains(contents, []byte("swagger")) {
+		return contents, nil, nil
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(contents, &doc); err != nil || doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		// Not ours to report, the document loader will fail with a proper error.
+		return contents, nil, nil
+	}
+
+	root := doc.Content[0]
+	version := yamlMapGet(root, "swagger")
+	if version == nil || !strings.HasPrefix(version.Value, "2") {
+		return contents, nil, nil
+	}
+
+	c := &swaggerConverter{
+		root:     root,
+		consumes: yamlStrings(yamlMapGet(root, "consumes")),
+		produces: yamlStrings(yamlMapGet(root, "produces")),
+		params:   yamlMapGet(root, "parameters"),
+	}
+	res, err := yaml.Marshal(c.convert())
+	if err != nil {
+		return nil, nil, fmt.Errorf("error converting Swagger 2.0 document: %w", err)
+	}
+
+	return res, c.warnings, nil
+}
+
+type swaggerConverter struct {
+	root     *yaml.Node
+	consumes []string
+	produces []string
+	params   *yaml.Node
+	warnings []string
+}
+
+func (c *swaggerConverter) warn(location, format string, args ...any) {
+	c.warnings = append(c.warnings, location+": "+fmt.Sprintf(format, args...))
+}
+
+func (c *swaggerConverter) convert() *yaml.Node {
+	res := yamlMap()
+	yamlMapSet(res, "openapi", yamlString(swaggerConvertedVersion))
+
+	for key, value := range yamlMapPairs(c.root) {
+		switch key {
+		case "swagger", "host", "basePath", "schemes", "consumes", "produces",
+			"definitions", "parameters", "responses", "securityDefinitions":
+			// converted below
+		case "info":
+			yamlMapSet(res, key, value)
+			if servers := c.convertServers(); servers != nil {
+				yamlMapSet(res, "servers", servers)
+			}
+		case "paths":
+			yamlMapSet(res, key, c.convertPaths(value))
+		case "tags", "externalDocs", "security":
+			yamlMapSet(res, key, value)
+		default:
+			if strings.HasPrefix(key, "x-") {
+				yamlMapSet(res, key, value)
+				continue
+			}
+			c.warn("#/"+key, "unknown field is dropped")
+		}
+	}
+
+	if components := c.convertComponents(); len(components.Content) > 0 {
+		yamlMapSet(res, "components", components)
+	}
+
+	return res
+}
+
+func (c *swaggerConverter) convertServers() *yaml.Node {
+	host := yamlMapGet(c.root, "host")
+	basePath := yamlMapGet(c.root, "basePath")
+	if host == nil && basePath == nil {
+		return nil
+	}
+
+	path := ""
+	if basePath != nil {
+		path = basePath.Value
+	}
+
+	servers := yamlSeq()
+	if host == nil {
+		server := yamlMap()
+		yamlMapSet(server, "url", yamlString(path))
+		servers.Content = append(servers.Content, server)
+		return servers
+	}
+
+	schemes := yamlStrings(yamlMapGet(c.root, "schemes"))
+	if len(schemes) == 0 {
+		schemes = []string{"https"}
+	}
+	for _, scheme := range schemes {
+		server := yamlMap()
+		yamlMapSet(server, "url", yamlString(scheme+"://"+host.Value+path))
+		servers.Content = append(servers.Content, server)
+	}
+
+	return servers
+}
+
+func (c *swaggerConverter) convertComponents() *yaml.Node {
+	res := yamlMap()
+
+	if definitions := yamlMapGet(c.root, "definitions"); definitions != nil {
+		schemas := yamlMap()
+		for name, schema := range yamlMapPairs(definitions) {
+			yamlMapSet(schemas, name, c.convertSchema(schema))
+		}
+		yamlMapSet(res, "schemas", schemas)
+	}
+
+	if c.params != nil {
+		params := yamlMap()
+		bodies := yamlMap()
+		for name, param := range yamlMapPairs(c.params) {
+			switch yamlMapValue(param, "in") {
+			case "body":
+				yamlMapSet(bodies, name, c.convertBodyParam(param, c.consumes))
+			case "formData":
+				// inlined into the request body of operations referencing it
+			default:
+				yamlMapSet(params, name, c.convertParam(param, "#/parameters/"+name))
+			}
+		}
+		if len(params.Content) > 0 {
+			yamlMapSet(res, "parameters", params)
+		}
+		if len(bodies.Content) > 0 {
+			yamlMapSet(res, "requestBodies", bodies)
+		}
+	}
+
+	if responses := yamlMapGet(c.root, "responses"); responses != nil {
+		converted := yamlMap()
+		for name, response := range yamlMapPairs(responses) {
+			yamlMapSet(converted, name, c.convertResponse(response, c.produces, "#/responses/"+name))
+		}
+		yamlMapSet(res, "responses", converted)
+	}
+
+	if definitions := yamlMapGet(c.root, "securityDefinitions"); definitions != nil {
+		schemes := yamlMap()
+		for name, definition := range yamlMapPairs(definitions) {
+			if scheme := c.convertSecurityScheme(definition, "#/securityDefinitions/"+name); scheme != nil {
+				yamlMapSet(schemes, name, scheme)
+			}
+		}
+		yamlMapSet(res, "securitySchemes", schemes)
+	}
+
+	return res
+}
+
+func (c *swaggerConverter) convertSecurityScheme(definition *yaml.Node, location string) *yaml.Node {
+	res := yamlMap()
+	typ := yamlMapValue(definition, "type")
+	switch typ {
+	case "basic":
+		yamlMapSet(res, "type", yamlString(SecuritySchemeTypeHTTP))
+		yamlMapSet(res, "scheme", yamlString("basic"))
+	case "apiKey":
+		yamlMapSet(res, "type", yamlString(SecuritySchemeTypeAPIKey))
+		yamlMapCopy(res, definition, "name", "in")
+	case "oauth2":
+		flowNames := map[string]string{
+			"implicit":    "implicit",
+			"password":    "password",
+			"application": "clientCredentials",
+			"accessCode":  "authorizationCode",
+		}
+		flowName, ok := flowNames[yamlMapValue(definition, "flow")]
+		if !ok {
+			c.warn(location, "unsupported oauth2 flow %q, scheme is dropped", yamlMapValue(definition, "flow"))
+			return nil
+		}
+
+		flow := yamlMap()
+		yamlMapCopy(flow, definition, "authorizationUrl", "tokenUrl")
+		scopes := yamlMapGet(definition, "scopes")
+		if scopes == nil {
+			scopes = yamlMap()
+		}
+		yamlMapSet(flow, "scopes", scopes)
+
+		flows := yamlMap()
+		yamlMapSet(flows, flowName, flow)
+		yamlMapSet(res, "type", yamlString(SecuritySchemeTypeOAuth2))
+		yamlMapSet(res, "flows", flows)
+	default:
+		c.warn(location, "unsupported security scheme type %q, scheme is dropped", typ)
+		return nil
+	}
+
+	yamlMapCopy(res, definition, "description")
+	yamlMapCopyExtensions(res, definition)
+
+	return res
+}
+
+func (c *swaggerConverter) convertPaths(paths *yaml.Node) *yaml.Node {
+	res := yamlMap()
+	for path, pathItem := range yamlMapPairs(paths) {
+		if strings.HasPrefix(path, "x-") {
+			yamlMapSet(res, path, pathItem)
+			continue
+		}
+		yamlMapSet(res, path, c.convertPathItem(pathItem, "#/paths/"+escapeJSONPointer(path)))
+	}
+	return res
+}
+
+func (c *swaggerConverter) convertPathItem(pathItem *yaml.Node, location string) *yaml.Node {
+	res := yamlMap()
+
+	// Body and form parameters become part of each operation's request body.
+	var (
+		pathParams    []*yaml.Node
+		sharedPayload []*yaml.Node
+	)
+	for _, param := range yamlSeqItems(yamlMapGet(pathItem, "parameters")) {
+		if in := c.resolveParam(param).in; in == "body" || in == "formData" {
+			sharedPayload = append(sharedPayload, param)
+			continue
+		}
+		pathParams = append(pathParams, param)
+	}
+
+	for key, value := range yamlMapPairs(pathItem) {
+		switch {
+		case key == "$ref":
+			c.warn(location, "path item $ref %q is kept as is, the referenced path item is not converted", value.Value)
+			yamlMapSet(res, key, value)
+		case key == "parameters":
+			if len(pathParams) > 0 {
+				params := yamlSeq()
+				for _, param := range pathParams {
+					params.Content = append(params.Content, c.convertParamOrRef(param, location+"/parameters"))
+				}
+				yamlMapSet(res, key, params)
+			}
+		case slices.Contains(swaggerOperationMethods, key):
+			yamlMapSet(res, key, c.convertOperation(value, sharedPayload, location+"/"+key))
+		case strings.HasPrefix(key, "x-"):
+			yamlMapSet(res, key, value)
+		default:
+			c.warn(location+"/"+key, "unknown field is dropped")
+		}
+	}
+
+	return res
+}
+
+func (c *swaggerConverter) convertOperation(operation *yaml.Node, sharedPayload []*yaml.Node, location string) *yaml.Node {
+	res := yamlMap()
+
+	consumes := c.consumes
+	if v := yamlMapGet(operation, "consumes"); v != nil {
+		consumes = yamlStrings(v)
+	}
+	produces := c.produces
+	if v := yamlMapGet(operation, "produces"); v != nil {
+		produces = yamlStrings(v)
+	}
+
+	var (
+		params    []*yaml.Node
+		body      *yaml.Node
+		formNames []string
+		form      []*yaml.Node
+	)
+	addPayload := func(param *yaml.Node) {
+		resolved := c.resolveParam(param)
+		switch resolved.in {
+		case "body":
+			if body != nil {
+				c.warn(location, "multiple body parameters, only the last one is kept")
+			}
+			body = param
+		case "formData":
+			// operation parameters override the path item ones
+			if i := slices.Index(formNames, resolved.name); i >= 0 {
+				form[i] = resolved.node
+				return
+			}
+			formNames = append(formNames, resolved.name)
+			form = append(form, resolved.node)
+		}
+	}
+	for _, param := range sharedPayload {
+		addPayload(param)
+	}
+	for _, param := range yamlSeqItems(yamlMapGet(operation, "parameters")) {
+		if in := c.resolveParam(param).in; in == "body" || in == "formData" {
+			addPayload(param)
+			continue
+		}
+		params = append(params, param)
+	}
+
+	for key, value := range yamlMapPairs(operation) {
+		switch key {
+		case "consumes", "produces":
+			// applied to the request body and responses
+		case "parameters":
+			if len(params) > 0 {
+				converted := yamlSeq()
+				for _, param := range params {
+					converted.Content = append(converted.Content, c.convertParamOrRef(param, location+"/parameters"))
+				}
+				yamlMapSet(res, key, converted)
+			}
+			switch {
+			case body != nil && form != nil:
+				c.warn(location, "operation has both body and formData parameters, formData parameters are dropped")
+				yamlMapSet(res, "requestBody", c.convertBodyParamOrRef(body, consumes))
+			case body != nil:
+				yamlMapSet(res, "requestBody", c.convertBodyParamOrRef(body, consumes))
+			case form != nil:
+				yamlMapSet(res, "requestBody", c.convertFormParams(form, consumes, location))
+			}
+		case "responses":
+			responses := yamlMap()
+			for code, response := range yamlMapPairs(value) {
+				if strings.HasPrefix(code, "x-") {
+					yamlMapSet(responses, code, response)
+					continue
+				}
+				yamlMapSet(responses, code, c.convertResponse(response, produces, location+"/responses/"+code))
+			}
+			yamlMapSet(res, key, responses)
+		case "schemes":
+			c.warn(location+"/schemes", "operation schemes are not supported, the global servers apply")
+		case "tags", "summary", "description", "externalDocs", "operationId", "deprecated", "security":
+			yamlMapSet(res, key, value)
+		default:
+			if strings.HasPrefix(key, "x-") {
+				yamlMapSet(res, key, value)
+				continue
+			}
+			c.warn(location+"/"+key, "unknown field is dropped")
+		}
+	}
+
+	// Payload parameters declared only on the path item
+	if yamlMapGet(operation, "parameters") == nil && (body != nil || form != nil) {
+		if body != nil {
+			yamlMapSet(res, "requestBody", c.convertBodyParamOrRef(body, consumes))
+		} else {
+			yamlMapSet(res, "requestBody", c.convertFormParams(form, consumes, location))
+		}
+	}
+
+	return res
+}
+
+type swaggerParam struct {
+	node *yaml.Node
+	name string
+	in   string
+}
+
+// resolveParam follows a reference to the global parameters.
+func (c *swaggerConverter) resolveParam(param *yaml.Node) swaggerParam {
+	node := param
+	if ref := yamlMapValue(param, "$ref"); strings.HasPrefix(ref, "#/parameters/") {
+		node = yamlMapGet(c.params, strings.TrimPrefix(ref, "#/parameters/"))
+		if node == nil {
+			return swaggerParam{node: param}
+		}
+	}
+	return swaggerParam{
+		node: node,
+		name: yamlMapValue(node, "name"),
+		in:   yamlMapValue(node, "in"),
+	}
+}
+
+func (c *swaggerConverter) convertParamOrRef(param *yaml.Node, location string) *yaml.Node {
+	if ref := yamlMapValue(param, "$ref"); ref != "" {
+		return yamlRef(swaggerRef(ref))
+	}
+	return c.convertParam(param, location)
+}
+
+func (c *swaggerConverter) convertParam(param *yaml.Node, location string) *yaml.Node {
+	res := yamlMap()
+	yamlMapCopy(res, param, "name", "in", "description", "required", "allowEmptyValue")
+	yamlMapCopyExtensions(res, param)
+	yamlMapSet(res, "schema", c.convertParamSchema(param))
+
+	in := yamlMapValue(param, "in")
+	if yamlMapValue(param, "type") != "array" {
+		return res
+	}
+
+	collectionFormat := yamlMapValue(param, "collectionFormat")
+	switch collectionFormat {
+	case "", "csv":
+		if in == "query" || in == "cookie" {
+			yamlMapSet(res, "style", yamlString("form"))
+			yamlMapSet(res, "explode", yamlBool(false))
+		}
+	case "multi":
+		yamlMapSet(res, "style", yamlString("form"))
+		yamlMapSet(res, "explode", yamlBool(true))
+	case "ssv":
+		yamlMapSet(res, "style", yamlString("spaceDelimited"))
+		yamlMapSet(res, "explode", yamlBool(false))
+	case "pipes":
+		yamlMapSet(res, "style", yamlString("pipeDelimited"))
+		yamlMapSet(res, "explode", yamlBool(false))
+	default:
+		c.warn(location, "collectionFormat %q of parameter %q has no OpenAPI 3 equivalent, the default style is used",
+			collectionFormat, yamlMapValue(param, "name"))
+	}
+
+	return res
+}
+
+// convertParamSchema builds the schema of a non-body parameter, header or items object.
+func (c *swaggerConverter) convertParamSchema(param *yaml.Node) *yaml.Node {
+	res := yamlMap()
+	for _, key := range swaggerParamSchemaKeys {
+		value := yamlMapGet(param, key)
+		if value == nil {
+			continue
+		}
+		if key == "items" {
+			value = c.convertParamSchema(value)
+		}
+		yamlMapSet(res, key, value)
+	}
+	if yamlMapValue(res, "type") == "file" {
+		yamlMapSet(res, "type", yamlString("string"))
+		yamlMapSet(res, "format", yamlString("binary"))
+	}
+	if ref := yamlMapValue(param, "$ref"); ref != "" {
+		return yamlRef(swaggerRef(ref))
+	}
+	return res
+}
+
+func (c *swaggerConverter) convertBodyParamOrRef(param *yaml.Node, consumes []string) *yaml.Node {
+	if ref := yamlMapValue(param, "$ref"); strings.HasPrefix(ref, "#/parameters/") {
+		return yamlRef("#/components/requestBodies/" + strings.TrimPrefix(ref, "#/parameters/"))
+	}
+	return c.convertBodyParam(param, consumes)
+}
+
+func (c *swaggerConverter) convertBodyParam(param *yaml.Node, consumes []string) *yaml.Node {
+	if len(consumes) == 0 {
+		consumes = []string{"application/json"}
+	}
+
+	content := yamlMap()
+	for _, mediaType := range consumes {
+		mt := yamlMap()
+		if schema := yamlMapGet(param, "schema"); schema != nil {
+			yamlMapSet(mt, "schema", c.convertSchema(schema))
+		}
+		yamlMapSet(content, mediaType, mt)
+	}
+
+	res := yamlMap()
+	yamlMapCopy(res, param, "description", "required")
+	yamlMapSet(res, "content", content)
+	yamlMapCopyExtensions(res, param)
+
+	return res
+}
+
+// convertFormParams merges the formData parameters into a single object schema.
+func (c *swaggerConverter) convertFormParams(params []*yaml.Node, consumes []string, location string) *yaml.Node {
+	properties := yamlMap()
+	required := yamlSeq()
+	encoding := yamlMap()
+	hasFile := false
+	for _, param := range params {
+		name := yamlMapValue(param, "name")
+		schema := c.convertParamSchema(param)
+		if description := yamlMapGet(param, "description"); description != nil {
+			yamlMapSet(schema, "description", description)
+		}
+		yamlMapSet(properties, name, schema)
+
+		if yamlMapValue(param, "required") == "true" {
+			required.Content = append(required.Content, yamlString(name))
+		}
+		if yamlMapValue(param, "type") == "file" {
+			hasFile = true
+		}
+		if yamlMapValue(param, "type") == "array" {
+			style := yamlMap()
+			explode := yamlMapValue(param, "collectionFormat") == "multi"
+			switch yamlMapValue(param, "collectionFormat") {
+			case "", "csv", "multi":
+				yamlMapSet(style, "style", yamlString("form"))
+			case "ssv":
+				yamlMapSet(style, "style", yamlString("spaceDelimited"))
+			case "pipes":
+				yamlMapSet(style, "style", yamlString("pipeDelimited"))
+			default:
+				c.warn(location, "collectionFormat %q of form parameter %q has no OpenAPI 3 equivalent, the default style is used",
+					yamlMapValue(param, "collectionFormat"), name)
+				continue
+			}
+			yamlMapSet(style, "explode", yamlBool(explode))
+			yamlMapSet(encoding, name, style)
+		}
+	}
+
+	schema := yamlMap()
+	yamlMapSet(schema, "type", yamlString("object"))
+	yamlMapSet(schema, "properties", properties)
+	if len(required.Content) > 0 {
+		yamlMapSet(schema, "required", required)
+	}
+
+	var mediaTypes []string
+	for _, mediaType := range consumes {
+		if strings.HasPrefix(mediaType, "multipart/form-data") || strings.HasPrefix(mediaType, "application/x-www-form-urlencoded") {
+			mediaTypes = append(mediaTypes, mediaType)
+		}
+	}
+	if len(mediaTypes) == 0 {
+		mediaTypes = []string{"application/x-www-form-urlencoded"}
+		if hasFile {
+			mediaTypes = []string{"multipart/form-data"}
+		}
+	}
+
+	content := yamlMap()
+	for _, mediaType := range mediaTypes {
+		mt := yamlMap()
+		yamlMapSet(mt, "schema", schema)
+		if len(encoding.Content) > 0 {
+			yamlMapSet(mt, "encoding", encoding)
+		}
+		yamlMapSet(content, mediaType, mt)
+	}
+
+	res := yamlMap()
+	if len(required.Content) > 0 {
+		yamlMapSet(res, "required", yamlBool(true))
+	}
+	yamlMapSet(res, "content", content)
+
+	return res
+}
+
+func (c *swaggerConverter) convertResponse(response *yaml.Node, produces []string, location string) *yaml.Node {
+	if ref := yamlMapValue(response, "$ref"); ref != "" {
+		return yamlRef(swaggerRef(ref))
+	}
+
+	res := yamlMap()
+	description := yamlMapGet(response, "description")
+	if description == nil {
+		description = yamlString("")
+	}
+	yamlMapSet(res, "description", description)
+
+	if headers := yamlMapGet(response, "headers"); headers != nil {
+		converted := yamlMap()
+		for name, header := range yamlMapPairs(headers) {
+			h := yamlMap()
+			yamlMapCopy(h, header, "description")
+			yamlMapSet(h, "schema", c.convertParamSchema(header))
+			converted.Content = append(converted.Content, yamlString(name), h)
+		}
+		yamlMapSet(res, "headers", converted)
+	}
+
+	examples := yamlMapGet(response, "examples")
+	if schema := yamlMapGet(response, "schema"); schema != nil {
+		if len(produces) == 0 {
+			produces = []string{"application/json"}
+		}
+		content := yamlMap()
+		for _, mediaType := range produces {
+			mt := yamlMap()
+			yamlMapSet(mt, "schema", c.convertSchema(schema))
+			if example := yamlMapGet(examples, mediaType); example != nil {
+				yamlMapSet(mt, "example", example)
+			}
+			yamlMapSet(content, mediaType, mt)
+		}
+		yamlMapSet(res, "content", content)
+	} else if examples != nil {
+		c.warn(location, "examples without a schema are dropped")
+	}
+
+	yamlMapCopyExtensions(res, response)
+
+	return res
+}
+
+// convertSchema rewrites the Swagger 2.0 specific parts of a schema.
+func (c *swaggerConverter) convertSchema(schema *yaml.Node) *yaml.Node {
+	switch schema.Kind {
+	case yaml.SequenceNode:
+		res := yamlSeq()
+		for _, item := range schema.Content {
+			res.Content = append(res.Content, c.convertSchema(item))
+		}
+		return res
+	case yaml.MappingNode:
+	default:
+		return schema
+	}
+
+	res := yamlMap()
+	for key, value := range yamlMapPairs(schema) {
+		switch key {
+		case "$ref":
+			yamlMapSet(res, key, yamlString(swaggerRef(value.Value)))
+		case "x-nullable":
+			yamlMapSet(res, "nullable", value)
+		case "discriminator":
+			discriminator := value
+			if value.Kind == yaml.ScalarNode {
+				discriminator = yamlMap()
+				yamlMapSet(discriminator, "propertyName", value)
+			}
+			yamlMapSet(res, key, discriminator)
+		case "type":
+			if value.Value == "file" {
+				yamlMapSet(res, key, yamlString("string"))
+				yamlMapSet(res, "format", yamlString("binary"))
+				continue
+			}
+			yamlMapSet(res, key, value)
+		case "properties", "patternProperties":
+			properties := yamlMap()
+			for name, property := range yamlMapPairs(value) {
+				properties.Content = append(properties.Content, yamlString(name), c.convertSchema(property))
+			}
+			yamlMapSet(res, key, properties)
+		case "items", "additionalProperties", "allOf", "anyOf", "oneOf", "not":
+			yamlMapSet(res, key, c.convertSchema(value))
+		default:
+			yamlMapSet(res, key, value)
+		}
+	}
+	if yamlMapValue(schema, "type") == "file" && yamlMapGet(schema, "format") != nil {
+		yamlMapSet(res, "format", yamlString("binary"))
+	}
+
+	return res
+}
+
+// swaggerExternalRefs returns the $refs of a Swagger 2.0 document pointing outside of it.
+func swaggerExternalRefs(contents []byte) []string {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(contents, &doc); err != nil {
+		return nil
+	}
+
+	var refs []string
+	var walk func(n *yaml.Node)
+	walk = func(n *yaml.Node) {
+		if n.Kind == yaml.MappingNode {
+			ref := yamlMapGet(n, "$ref")
+			if ref != nil && ref.Kind == yaml.ScalarNode && !strings.HasPrefix(ref.Value, "#") && !slices.Contains(refs, ref.Value) {
+				refs = append(refs, ref.Value)
+			}
+		}
+		for _, child := range n.Content {
+			walk(child)
+		}
+	}
+	walk(&doc)
+
+	return refs
+}
+
+// swaggerRef rewrites a local Swagger 2.0 reference to its OpenAPI 3 location.
+func swaggerRef(ref string) string {
+	for from, to := range map[string]string{
+		"#/definitions/": "#/components/schemas/",
+		"#/parameters/":  "#/components/parameters/",
+		"#/responses/":   "#/components/responses/",
+	} {
+		if strings.HasPrefix(ref, from) {
+			return to + strings.TrimPrefix(ref, from)
+		}
+	}
+	return ref
+}
+
+func escapeJSONPointer(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "~", "~0"), "/", "~1")
+}
+
+func yamlMap() *yaml.Node {
+	return &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+}
+
+func yamlSeq() *yaml.Node {
+	return &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+}
+
+func yamlString(s string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: s}
+}
+
+func yamlBool(b bool) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: fmt.Sprintf("%t", b)}
+}
+
+func yamlRef(ref string) *yaml.Node {
+	res := yamlMap()
+	yamlMapSet(res, "$ref", yamlString(ref))
+	return res
+}
+
+// yamlMapPairs iterates over the key-value pairs of a mapping node in document order.
+func yamlMapPairs(n *yaml.Node) func(yield func(string, *yaml.Node) bool) {
+	return func(yield func(string, *yaml.Node) bool) {
+		if n == nil || n.Kind != yaml.MappingNode {
+			return
+		}
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			if !yield(n.Content[i].Value, n.Content[i+1]) {
+				return
+			}
+		}
+	}
+}
+
+func yamlMapGet(n *yaml.Node, key string) *yaml.Node {
+	for k, v := range yamlMapPairs(n) {
+		if k == key {
+			return v
+		}
+	}
+	return nil
+}
+
+// yamlMapValue returns the scalar value of key, or an empty string.
+func yamlMapValue(n *yaml.Node, key string) string {
+	if v := yamlMapGet(n, key); v != nil && v.Kind == yaml.ScalarNode {
+		return v.Value
+	}
+	return ""
+}
+
+func yamlMapSet(n *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == key {
+			n.Content[i+1] = value
+			return
+		}
+	}
+	n.Content = append(n.Content, yamlString(key), value)
+}
+
+// yamlMapCopy copies the given keys from src to dst, if present.
+func yamlMapCopy(dst, src *yaml.Node, keys ...string) {
+	for _, key := range keys {
+		if v := yamlMapGet(src, key); v != nil {
+			yamlMapSet(dst, key, v)
+		}
+	}
+}
+
+func yamlMapCopyExtensions(dst, src *yaml.Node) {
+	for k, v := range yamlMapPairs(src) {
+		if strings.HasPrefix(k, "x-") {
+			yamlMapSet(dst, k, v)
+		}
+	}
+}
+
+func yamlSeqItems(n *yaml.Node) []*yaml.Node {
+	if n == nil || n.Kind != yaml.SequenceNode {
+		return nil
+	}
+	return n.Content
+}
+
+func yamlStrings(n *yaml.Node) []string {
+	var res []string
+	for _, item := range yamlSeqItems(n) {
+		res = append(res, item.Value)
+	}
+	return res
+}
@@ -27,7 +27,7 @@ func preRegisterSchemaNames(schemas *orderedmap.Map[string, *base.SchemaProxy],
 	schemaNames := make(map[string]string) // schemaName -> goTypeName
 
 	for schemaName, schemaRef := range schemas.FromOldest() {
-		goTypeName, err := renameComponent(schemaNameToTypeName(schemaName), schemaRef)
+		goTypeName, err := options.namer().renameComponent(options.namer().componentSchemaTypeName(schemaName), schemaRef)
 		if err != nil {
 			return nil, fmt.Errorf("error making name for components/schemas/%s: %w", schemaName, err)
 		}
@@ -55,7 +55,10 @@ func generateSchemaDefinitions(schemas *orderedmap.Map[string, *base.SchemaProxy
 
 	for schemaName, schemaRef := range schemas.FromOldest() {
 		ref := schemaRef.GoLow().GetReference()
-		opts := options.WithReference(ref).WithPath([]string{schemaName})
+		opts := options.
+			WithReference(ref).
+			WithPath([]string{schemaName}).
+			WithXMLTags(options.xmlRefs["#/components/schemas/"+schemaName])
 		goSchema, err := GenerateGoSchema(schemaRef, opts)
 		if err != nil {
 			return nil, fmt.Errorf("error converting GoSchema %s to Go type: %w", schemaName, err)
@@ -95,7 +98,7 @@ func getComponentParameters(params *orderedmap.Map[string, *v3high.Parameter], o
 			return nil, fmt.Errorf("error generating Go type for schema in parameter %s: %w", paramName, err)
 		}
 
-		goTypeName, err := renameParameter(paramName, paramOrRef)
+		goTypeName, err := options.namer().renameParameter(paramName, paramOrRef)
 		if err != nil {
 			return nil, fmt.Errorf("error making name for components/parameters/%s: %w", paramName, err)
 		}
@@ -129,11 +132,11 @@ func getComponentParameters(params *orderedmap.Map[string, *v3high.Parameter], o
 				goTypeName = registeredName
 			} else {
 				// Fall back to extracting the name from the ref path
-				refType, err := refPathToGoType(ref)
+				refType, err := options.namer().refPathToGoType(ref)
 				if err != nil {
 					return nil, fmt.Errorf("error generating Go type for (%s) in parameter %s: %w", ref, paramName, err)
 				}
-				goTypeName = schemaNameToTypeName(refType)
+				goTypeName = options.namer().schemaNameToTypeName(refType)
 			}
 		}
 
@@ -178,7 +181,7 @@ func getComponentsRequestBodies(bodies *orderedmap.Map[string, *v3high.RequestBo
 				continue
 			}
 
-			goTypeName, err := renameComponent(schemaNameToTypeName(requestBodyName), body.Schema)
+			goTypeName, err := options.namer().renameComponent(options.namer().schemaNameToTypeName(requestBodyName), body.Schema)
 			if err != nil {
 				return nil, fmt.Errorf("error making name for components/schemas/%s: %w", requestBodyName, err)
 			}
@@ -216,11 +219,11 @@ func getComponentsRequestBodies(bodies *orderedmap.Map[string, *v3high.RequestBo
 					typeDef.Name = registeredName
 				} else {
 					// Fall back to extracting the name from the ref path
-					refType, err := refPathToGoType(bodyRef)
+					refType, err := options.namer().refPathToGoType(bodyRef)
 					if err != nil {
 						return nil, fmt.Errorf("error generating Go type for (%s) in body %s: %w", bodyRef, requestBodyName, err)
 					}
-					typeDef.Name = schemaNameToTypeName(refType)
+					typeDef.Name = options.namer().schemaNameToTypeName(refType)
 				}
 			}
 			types = append(types, typeDef)
@@ -249,7 +252,7 @@ func getComponentResponses(responses *orderedmap.Map[string, *v3high.Response],
 				return nil, fmt.Errorf("error generating Go type for schema in response %s: %w", responseName, err)
 			}
 
-			goTypeName, err := renameComponent(schemaNameToTypeName(responseName), content.Schema)
+			goTypeName, err := options.namer().renameComponent(options.namer().schemaNameToTypeName(responseName), content.Schema)
 			if err != nil {
 				return nil, fmt.Errorf("error making name for components/responses/%s: %w", responseName, err)
 			}
@@ -288,12 +291,12 @@ func getComponentResponses(responses *orderedmap.Map[string, *v3high.Response],
 					renamed = registeredName
 				} else {
 					// Fall back to extracting the name from the ref path
-					refType, err := refPathToGoType(contentRef)
+					refType, err := options.namer().refPathToGoType(contentRef)
 					if err != nil {
 						return nil, fmt.Errorf("error generating Go type for (%s) in response %s: %w",
 							content.Schema.GetReference(), responseName, err)
 					}
-					renamed = schemaNameToTypeName(refType)
+					renamed = options.namer().schemaNameToTypeName(refType)
 				}
 
 				// Only set RefType if it's different from the type name to avoid self-reference.
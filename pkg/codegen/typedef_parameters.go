@@ -50,6 +50,7 @@ type ParameterDefinition struct {
 	Spec           *v3high.Parameter
 	Schema         GoSchema
 	resolvedGoName string // The actual Go field name after conflict resolution (set by generateParamsTypes)
+	naming         *namer
 }
 
 // TypeDef is here as an adapter after a large refactoring so that I don't
@@ -117,8 +118,12 @@ func (pd ParameterDefinition) GoName() string {
 	if pd.resolvedGoName != "" {
 		return pd.resolvedGoName
 	}
+	naming := pd.naming
+	if naming == nil {
+		naming = defaultNamer
+	}
 	exts := extractExtensions(pd.Spec.Extensions)
-	return createPropertyGoFieldName(pd.ParamName, exts)
+	return naming.createPropertyGoFieldName(pd.ParamName, exts)
 }
 
 // IsPointerType returns true if this parameter's field in the generated struct is a pointer.
@@ -241,6 +246,7 @@ func describeOperationParameters(params []*v3high.Parameter, options ParseOption
 			Required:  required,
 			Spec:      param,
 			Schema:    goSchema,
+			naming:    options.namer(),
 		}
 
 		// If the parameter references a component parameter, use the registered type name
@@ -253,7 +259,7 @@ func describeOperationParameters(params []*v3high.Parameter, options ParseOption
 			// name as the type. $ref: "#/components/schemas/custom_type" becomes "CustomType".
 			// However, for deep path references (e.g., #/paths/.../parameters/1/schema),
 			// GenerateGoSchema has already created the type definition, so we don't override it.
-			goType, err := refPathToGoType(schemaRef)
+			goType, err := options.namer().refPathToGoType(schemaRef)
 			if err != nil {
 				return nil, fmt.Errorf("error dereferencing (%s) for param (%s): %s", schemaRef, param.Name, err)
 			}
@@ -343,7 +349,7 @@ func generateParamsTypes(objectParams []ParameterDefinition, typeName string, op
 		}
 
 		// Generate the Go field name and handle conflicts
-		baseGoName := createPropertyGoFieldName(param.ParamName, exts)
+		baseGoName := options.namer().createPropertyGoFieldName(param.ParamName, exts)
 		goName := baseGoName
 		if count, exists := goFieldNames[baseGoName]; exists {
 			// Conflict detected - append a number
@@ -56,6 +56,9 @@ type RequestBodyEncoding struct {
 	ContentType string
 	Style       string
 	Explode     *bool
+
+	// Headers holds the multipart part headers declared with a default value.
+	Headers map[string]string
 }
 
 // createBodyDefinition turns the OpenAPI body definitions into a list of our body definitions
@@ -90,7 +93,7 @@ func createBodyDefinition(operationID string, body *v3high.RequestBody, options
 		tag = "JSON"
 		defaultBody = true
 	case isMediaTypeJson(contentType):
-		tag = mediaTypeToCamelCase(contentType)
+		tag = options.namer().mediaTypeToCamelCase(contentType)
 	case strings.HasPrefix(contentType, "multipart/"):
 		tag = "Multipart"
 	case contentType == "application/x-www-form-urlencoded":
@@ -99,15 +102,21 @@ func createBodyDefinition(operationID string, body *v3high.RequestBody, options
 		tag = "Text"
 	case contentType == "text/html":
 		tag = "HTML"
+	case isMediaTypeXML(contentType):
+		tag = "XML"
 	default:
-		// For unsupported content types (XML, binary, etc.), create a "Raw" body definition.
+		// For unsupported content types (YAML, binary, etc.), create a "Raw" body definition.
 		// This ensures opts are generated so users can access RawRequest for custom parsing.
 		tag = "Raw"
 	}
 
 	bodyTypeName := operationID + "Body"
 	ref := schemaProxy.GoLow().GetReference()
-	opts := options.WithReference(ref).WithPath([]string{bodyTypeName}).WithSpecLocation(SpecLocationBody)
+	opts := options.
+		WithReference(ref).
+		WithPath([]string{bodyTypeName}).
+		WithSpecLocation(SpecLocationBody).
+		WithXMLTags(isMediaTypeXML(contentType))
 
 	// For request bodies, filter out readOnly fields from the required list
 	// since readOnly fields should only appear in responses, not requests
@@ -162,6 +171,17 @@ func createBodyDefinition(operationID string, body *v3high.RequestBody, options
 				Style:       v.Style,
 				Explode:     v.Explode,
 			}
+			for name, header := range v.Headers.FromOldest() {
+				if header == nil || header.Schema == nil || strings.EqualFold(name, "Content-Type") {
+					continue
+				}
+				if schema := header.Schema.Schema(); schema != nil && schema.Default != nil {
+					if enc.Headers == nil {
+						enc.Headers = make(map[string]string)
+					}
+					enc.Headers[name] = schema.Default.Value
+				}
+			}
 			bd.Encoding[k] = enc
 		}
 	}
@@ -13,9 +13,13 @@ package codegen
 import (
 	"fmt"
 	"iter"
+	"maps"
+	"mime"
+	"slices"
 	"strconv"
 	"strings"
 
+	"github.com/pb33f/libopenapi/datamodel/high/base"
 	v3high "github.com/pb33f/libopenapi/datamodel/high/v3"
 )
 
@@ -25,6 +29,69 @@ type ResponseDefinition struct {
 	Success           *ResponseContentDefinition
 	Error             *ResponseContentDefinition
 	All               map[int]*ResponseContentDefinition
+
+	// Default is the response declared for all status codes not listed explicitly.
+	// When no error responses are declared, it is also stored in All under 500 and used as Error.
+	Default *ResponseContentDefinition
+
+	// NoContentErrors are the error responses declared without a body, which are not in All.
+	NoContentErrors []*ResponseContentDefinition
+}
+
+// StrictResponse describes a response a strict handler service can return, see HandlerOptions.Strict.
+type StrictResponse struct {
+	*ResponseContentDefinition
+	// Suffix names the type of the response after the operation, e.g. 202JSON, 204 or DefaultJSON.
+	Suffix string
+	// IsDefault is true for the default response, whose status code is set by the service.
+	IsDefault bool
+	// NoContent is true for responses without a body.
+	NoContent bool
+}
+
+// StrictResponses returns the responses a strict handler service can return, ordered by status code,
+// the default response being last. Event streams are not included.
+func (r ResponseDefinition) StrictResponses() []StrictResponse {
+	var res []StrictResponse
+	for _, rcd := range slices.Concat(slices.Collect(maps.Values(r.All)), r.NoContentErrors) {
+		if rcd.IsEventStream || rcd == r.Default {
+			continue
+		}
+		noContent := rcd.ResponseName == "struct{}"
+		suffix := strconv.Itoa(rcd.StatusCode)
+		if !noContent {
+			suffix += rcd.envelopePrefix()
+		}
+		res = append(res, StrictResponse{ResponseContentDefinition: rcd, Suffix: suffix, NoContent: noContent})
+	}
+	slices.SortFunc(res, func(a, b StrictResponse) int {
+		return a.StatusCode - b.StatusCode
+	})
+
+	if r.Default != nil {
+		res = append(res, StrictResponse{
+			ResponseContentDefinition: r.Default,
+			Suffix:                    "Default" + r.Default.envelopePrefix(),
+			IsDefault:                 true,
+		})
+	}
+	return res
+}
+
+// WithContent returns the responses with a body declared for an explicit status code, ordered by status code.
+// The default response and event streams are not included.
+func (r ResponseDefinition) WithContent() []*ResponseContentDefinition {
+	var res []*ResponseContentDefinition
+	for _, rcd := range r.All {
+		if rcd == r.Default || rcd.ResponseName == "struct{}" || rcd.IsEventStream {
+			continue
+		}
+		res = append(res, rcd)
+	}
+	slices.SortFunc(res, func(a, b *ResponseContentDefinition) int {
+		return a.StatusCode - b.StatusCode
+	})
+	return res
 }
 
 // ResponseContentDefinition describes Operation response.
@@ -46,9 +113,42 @@ type ResponseContentDefinition struct {
 	IsSuccess    bool
 	StatusCode   int
 	Headers      map[string]GoSchema
-	// IsRaw is true for unsupported content types (XML, form-urlencoded, etc.)
+	// IsRaw is true for unsupported content types (YAML, etc.)
 	// that require the user to handle marshaling manually.
 	IsRaw bool
+	// IsEventStream is true for text/event-stream responses, Schema being the schema of the event data.
+	IsEventStream bool
+	// TypedHeaders describes the struct decoding the headers declared by the success response,
+	// nil for other responses and when no headers are declared.
+	TypedHeaders *ResponseHeadersDefinition
+}
+
+// ResponseHeadersDefinition describes the <Operation>ResponseHeaders struct holding the typed response headers.
+// Properties are in declaration order, with JsonFieldName being the header name.
+type ResponseHeadersDefinition struct {
+	Name       string
+	Properties []Property
+}
+
+// EnvelopeField returns the name of the response envelope field holding this response, e.g. JSON200.
+func (r *ResponseContentDefinition) EnvelopeField() string {
+	return r.envelopePrefix() + strconv.Itoa(r.StatusCode)
+}
+
+func (r *ResponseContentDefinition) envelopePrefix() string {
+	switch {
+	case isMediaTypeJson(r.ContentType):
+		return "JSON"
+	case isMediaTypeXML(r.ContentType):
+		return "XML"
+	case strings.HasPrefix(r.ContentType, "application/x-www-form-urlencoded"):
+		return "Formdata"
+	case strings.HasPrefix(r.ContentType, "text/plain"):
+		return "Text"
+	case strings.HasPrefix(r.ContentType, "text/html"):
+		return "HTML"
+	}
+	return "Body"
 }
 
 func getOperationResponses(operationID string, responses *v3high.Responses, options ParseOptions) (*ResponseDefinition, []TypeDefinition, error) {
@@ -62,6 +162,8 @@ func getOperationResponses(operationID string, responses *v3high.Responses, opti
 	)
 
 	all := make(map[int]*ResponseContentDefinition)
+	var noContentErrors []*ResponseContentDefinition
+	specResponses := make(map[int]*v3high.Response) // spec responses by status code, for their headers
 
 	// If responses is nil, create a default 204 No Content response
 	if responses == nil {
@@ -98,7 +200,7 @@ func getOperationResponses(operationID string, responses *v3high.Responses, opti
 		// Check if this response is a $ref to a component response
 		responseRef := response.GoLow().GetReference()
 		if responseRef != "" {
-			refType, err = refPathToGoType(responseRef)
+			refType, err = options.namer().refPathToGoType(responseRef)
 			if err != nil {
 				return nil, nil, fmt.Errorf("error turning reference (%s) into a Go type: %w", responseRef, err)
 			}
@@ -142,6 +244,7 @@ func getOperationResponses(operationID string, responses *v3high.Responses, opti
 		if fstSuccessCode == 0 && isSuccess {
 			fstSuccessCode = status
 		}
+		specResponses[status] = response
 
 		var (
 			contentType string
@@ -158,16 +261,28 @@ func getOperationResponses(operationID string, responses *v3high.Responses, opti
 			}
 		}
 
-		if content == nil || content.Schema == nil {
+		// The events of a stream are described by the item schema (OpenAPI 3.2), or the schema
+		isEventStream := isEventStreamContentType(contentType)
+		var schemaProxy *base.SchemaProxy
+		if content != nil {
+			schemaProxy = content.Schema
+			if isEventStream && content.ItemSchema != nil {
+				schemaProxy = content.ItemSchema
+			}
+		}
+
+		if schemaProxy == nil {
+			definition := &ResponseContentDefinition{
+				IsSuccess:    isSuccess,
+				Description:  response.Description,
+				ResponseName: "struct{}",
+				StatusCode:   status,
+				Headers:      headers,
+			}
 			if isSuccess {
-				successDefinition := &ResponseContentDefinition{
-					IsSuccess:    isSuccess,
-					Description:  response.Description,
-					ResponseName: "struct{}",
-					StatusCode:   status,
-					Headers:      headers,
-				}
-				all[status] = successDefinition
+				all[status] = definition
+			} else {
+				noContentErrors = append(noContentErrors, definition)
 			}
 			continue
 		}
@@ -191,7 +306,7 @@ func getOperationResponses(operationID string, responses *v3high.Responses, opti
 			WithReference("").
 			WithPath(pathParts).
 			WithSpecLocation(SpecLocationResponse)
-		contentSchema, err := GenerateGoSchema(content.Schema, options)
+		contentSchema, err := GenerateGoSchema(schemaProxy, options.WithXMLTags(isMediaTypeXML(contentType)))
 		if err != nil {
 			return nil, nil, fmt.Errorf("error generating request body definition: %w", err)
 		}
@@ -199,9 +314,9 @@ func getOperationResponses(operationID string, responses *v3high.Responses, opti
 			continue
 		}
 
-		// For raw content types (XML, YAML, etc.), override the schema to []byte
+		// For raw content types (YAML, etc.), override the schema to []byte
 		// since we can't automatically unmarshal these formats.
-		if isRawContentType(contentType) {
+		if isRawContentType(contentType) && !isEventStream {
 			contentSchema = GoSchema{
 				GoType:         "[]byte",
 				DefineViaAlias: true,
@@ -217,7 +332,7 @@ func getOperationResponses(operationID string, responses *v3high.Responses, opti
 		// Otherwise, generate a dynamic name and create a TypeDefinition.
 		componentTypeName := ""
 		if isComponentRef {
-			componentTypeName = schemaNameToTypeName(refType)
+			componentTypeName = options.namer().schemaNameToTypeName(refType)
 		}
 
 		// Check if the component type actually exists AND is a response type.
@@ -317,7 +432,7 @@ func getOperationResponses(operationID string, responses *v3high.Responses, opti
 			case contentType == "application/json":
 				tag = "JSON"
 			case isMediaTypeJson(contentType):
-				tag = mediaTypeToCamelCase(contentType)
+				tag = options.namer().mediaTypeToCamelCase(contentType)
 			case contentType == "application/x-www-form-urlencoded":
 				tag = "Formdata"
 			case strings.HasPrefix(contentType, "multipart/"):
@@ -382,19 +497,20 @@ func getOperationResponses(operationID string, responses *v3high.Responses, opti
 
 		// IsRaw is true for unsupported content types that require manual marshaling
 		// Use HasPrefix to handle content types with parameters (e.g., "text/html; charset=UTF-8")
-		isRaw := isRawContentType(contentType)
+		isRaw := isRawContentType(contentType) && !isEventStream
 
 		rcd := &ResponseContentDefinition{
-			ResponseName: responseName,
-			IsSuccess:    isSuccess,
-			Description:  response.Description,
-			Schema:       contentSchema,
-			Ref:          refType,
-			ContentType:  contentType,
-			NameTag:      tag,
-			StatusCode:   status,
-			Headers:      headers,
-			IsRaw:        isRaw,
+			ResponseName:  responseName,
+			IsSuccess:     isSuccess,
+			Description:   response.Description,
+			Schema:        contentSchema,
+			Ref:           refType,
+			ContentType:   contentType,
+			NameTag:       tag,
+			StatusCode:    status,
+			Headers:       headers,
+			IsRaw:         isRaw,
+			IsEventStream: isEventStream,
 		}
 		all[status] = rcd
 	}
@@ -411,6 +527,7 @@ func getOperationResponses(operationID string, responses *v3high.Responses, opti
 		all[successCode] = successDefinition
 	}
 
+	var defaultDefinition *ResponseContentDefinition
 	if errorCode == 0 && defaultResponse != nil {
 		errorCode = 500
 		fstErrorCode = 500
@@ -431,7 +548,10 @@ func getOperationResponses(operationID string, responses *v3high.Responses, opti
 			if contentVal.Schema != nil {
 				ref = contentVal.Schema.GetReference()
 
-				opts := options.WithReference(ref).WithPath([]string{operationID, typeSuffix})
+				opts := options.
+					WithReference(ref).
+					WithPath([]string{operationID, typeSuffix}).
+					WithXMLTags(isMediaTypeXML(contentType))
 				contentSchema, err = GenerateGoSchema(contentVal.Schema, opts)
 				if err != nil {
 					return nil, nil, fmt.Errorf("error generating request body definition: %w", err)
@@ -440,7 +560,7 @@ func getOperationResponses(operationID string, responses *v3high.Responses, opti
 		}
 
 		if ref != "" {
-			refType, err = refPathToGoType(ref)
+			refType, err = options.namer().refPathToGoType(ref)
 			if err != nil {
 				return nil, nil, fmt.Errorf("error turning reference (%s) into a Go type: %w", ref, err)
 			}
@@ -487,7 +607,27 @@ func getOperationResponses(operationID string, responses *v3high.Responses, opti
 				Headers:      errHeaders,
 			}
 			all[errorCode] = errorDefinition
+			defaultDefinition = errorDefinition
+		}
+	} else if defaultResponse != nil && options.IncludeDefaultResponse {
+		var (
+			defaultTypes []TypeDefinition
+			err          error
+		)
+		defaultDefinition, defaultTypes, err = getDefaultResponse(operationID, defaultResponse, options)
+		if err != nil {
+			return nil, nil, err
+		}
+		typeDefinitions = append(typeDefinitions, defaultTypes...)
+	}
+
+	if response, ok := specResponses[successCode]; ok && response.Headers != nil && all[successCode] != nil {
+		typedHeaders, headerTypes, err := generateResponseHeadersType(operationID, response.Headers.FromOldest(), options)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error generating response headers type: %w", err)
 		}
+		all[successCode].TypedHeaders = typedHeaders
+		typeDefinitions = append(typeDefinitions, headerTypes...)
 	}
 
 	res := &ResponseDefinition{
@@ -495,11 +635,99 @@ func getOperationResponses(operationID string, responses *v3high.Responses, opti
 		Success:           all[successCode],
 		Error:             all[fstErrorCode],
 		All:               all,
+		Default:           defaultDefinition,
+		NoContentErrors:   noContentErrors,
 	}
 
 	return res, typeDefinitions, nil
 }
 
+// getDefaultResponse describes the default response of an operation that also declares explicit error responses.
+// Component schemas are used as is, inline schemas get a <Operation>DefaultResponse type.
+func getDefaultResponse(operationID string, response *v3high.Response, options ParseOptions) (*ResponseContentDefinition, []TypeDefinition, error) {
+	if response.Content == nil {
+		return nil, nil, nil
+	}
+
+	var (
+		contentType string
+		content     *v3high.MediaType
+	)
+	if pair, ok := response.Content.Get("application/json"); ok {
+		contentType, content = "application/json", pair
+	} else if v := response.Content.First(); v != nil {
+		contentType, content = v.Key(), v.Value()
+	}
+	if content == nil || content.Schema == nil {
+		return nil, nil, nil
+	}
+
+	ref := content.Schema.GetReference()
+	opts := options.
+		WithReference(ref).
+		WithPath([]string{operationID, "DefaultResponse"}).
+		WithSpecLocation(SpecLocationResponse).
+		WithXMLTags(isMediaTypeXML(contentType))
+	contentSchema, err := GenerateGoSchema(content.Schema, opts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error generating default response definition: %w", err)
+	}
+	if contentSchema.IsZero() {
+		return nil, nil, nil
+	}
+
+	headers, err := generateResponseHeadersSchema(response.Headers.FromOldest(), operationID, options)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error generating response headers schema: %w", err)
+	}
+
+	isRaw := isRawContentType(contentType)
+	rcd := &ResponseContentDefinition{
+		Description: response.Description,
+		Schema:      contentSchema,
+		ContentType: contentType,
+		Headers:     headers,
+		IsRaw:       isRaw,
+	}
+
+	if ref != "" && !isRaw {
+		rcd.ResponseName = contentSchema.GoType
+		rcd.Ref = contentSchema.GoType
+		return rcd, nil, nil
+	}
+
+	if isRaw {
+		contentSchema = GoSchema{
+			GoType:         "[]byte",
+			DefineViaAlias: true,
+			Description:    contentSchema.Description,
+		}
+		rcd.Schema = contentSchema
+	} else if contentSchema.ArrayType != nil {
+		contentSchema, _ = replaceInlineTypes(contentSchema, options)
+		rcd.Schema = contentSchema
+	}
+
+	rcd.ResponseName = options.typeTracker.generateUniqueName(operationID + "DefaultResponse")
+	td := TypeDefinition{
+		Name:           rcd.ResponseName,
+		Schema:         contentSchema,
+		SpecLocation:   SpecLocationResponse,
+		NeedsMarshaler: needsMarshaler(contentSchema),
+	}
+	options.typeTracker.register(td, "")
+
+	typeDefinitions := []TypeDefinition{td}
+	for _, additionalType := range contentSchema.AdditionalTypes {
+		if _, exists := options.typeTracker.LookupByName(additionalType.Name); !exists {
+			typeDefinitions = append(typeDefinitions, additionalType)
+			options.typeTracker.register(additionalType, "")
+		}
+	}
+
+	return rcd, typeDefinitions, nil
+}
+
 func generateResponseHeadersSchema(headers iter.Seq2[string, *v3high.Header], operationID string, options ParseOptions) (map[string]GoSchema, error) {
 	res := make(map[string]GoSchema)
 	opts := options.WithReference("").WithPath([]string{operationID, "Header"})
@@ -514,13 +742,120 @@ func generateResponseHeadersSchema(headers iter.Seq2[string, *v3high.Header], op
 	return res, nil
 }
 
+// generateResponseHeadersType defines the <Operation>ResponseHeaders struct holding the given headers.
+// Content-Type is skipped, as the OpenAPI specification requires, and headers of object type are kept as strings.
+// It returns nil when no header is left.
+func generateResponseHeadersType(operationID string, headers iter.Seq2[string, *v3high.Header], options ParseOptions) (*ResponseHeadersDefinition, []TypeDefinition, error) {
+	var (
+		typeDefs   []TypeDefinition
+		properties []Property
+	)
+	goFieldNames := make(map[string]int)
+
+	for name, header := range headers {
+		if header == nil || strings.EqualFold(name, "Content-Type") {
+			continue
+		}
+
+		hSchema := GoSchema{GoType: "string"}
+		var oapiSchema *base.Schema
+		if header.Schema != nil {
+			oapiSchema = header.Schema.Schema()
+		}
+		if oapiSchema != nil && !slices.Contains(oapiSchema.Type, "object") {
+			opts := options.
+				WithReference(header.Schema.GetReference()).
+				WithPath([]string{operationID, "ResponseHeaders", name}).
+				WithSpecLocation(SpecLocationResponse)
+			var err error
+			if hSchema, err = GenerateGoSchema(header.Schema, opts); err != nil {
+				return nil, nil, fmt.Errorf("error generating type for header %s: %w", name, err)
+			}
+			typeDefs = append(typeDefs, hSchema.AdditionalTypes...)
+		}
+
+		exts := extractExtensions(header.Extensions)
+		goName := options.namer().createPropertyGoFieldName(name, exts)
+		if count, exists := goFieldNames[goName]; exists {
+			goFieldNames[goName] = count + 1
+			goName = fmt.Sprintf("%s%d", goName, count+1)
+		} else {
+			goFieldNames[goName] = 0
+		}
+
+		constraints := newConstraints(oapiSchema, ConstraintsContext{
+			required:     header.Required,
+			specLocation: SpecLocationResponse,
+		})
+		// Required numeric headers are always sent, so zero stays a valid value.
+		if header.Required && oapiSchema != nil && (slices.Contains(oapiSchema.Type, "integer") || slices.Contains(oapiSchema.Type, "number")) {
+			constraints.Required = nil
+			constraints.ValidationTags = slices.DeleteFunc(constraints.ValidationTags, func(tag string) bool {
+				return tag == "required"
+			})
+		}
+
+		properties = append(properties, Property{
+			GoName:        goName,
+			Description:   header.Description,
+			JsonFieldName: name,
+			Schema:        hSchema,
+			Extensions:    exts,
+			Deprecated:    header.Deprecated,
+			Constraints:   constraints,
+		})
+	}
+	if len(properties) == 0 {
+		return nil, nil, nil
+	}
+
+	typeName := operationID + "ResponseHeaders"
+	if options.typeTracker.Exists(typeName) {
+		typeName = options.typeTracker.generateUniqueName(typeName)
+	}
+
+	// Placed with the header parameters, so that it gets a Validate() method like them.
+	s := GoSchema{Properties: properties}
+	s.GoType = s.createGoStruct(genFieldsFromProperties(properties, options))
+	td := TypeDefinition{
+		Name:         typeName,
+		Schema:       s,
+		SpecLocation: SpecLocationHeader,
+	}
+	options.typeTracker.register(td, "")
+
+	return &ResponseHeadersDefinition{Name: typeName, Properties: properties}, append(typeDefs, td), nil
+}
+
+// isStreamingResponse returns true for binary and event stream success responses,
+// which the client streams instead of buffering.
+func isStreamingResponse(rcd *ResponseContentDefinition) bool {
+	if rcd == nil || rcd.ContentType == "" {
+		return false
+	}
+	if rcd.IsEventStream {
+		return true
+	}
+	if mediaType, _, err := mime.ParseMediaType(rcd.ContentType); err == nil && mediaType == "application/octet-stream" {
+		return true
+	}
+	return !isMediaTypeJson(rcd.ContentType) && rcd.Schema.OpenAPISchema != nil && rcd.Schema.OpenAPISchema.Format == "binary"
+}
+
+// isEventStreamContentType returns true for Server-Sent Events streams.
+func isEventStreamContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && mediaType == "text/event-stream"
+}
+
 // isRawContentType returns true for content types that require manual marshaling
-// (XML, YAML, etc.) and should use []byte as the response type.
+// (YAML, etc.) and should use []byte as the response type.
 func isRawContentType(contentType string) bool {
 	return contentType != "" &&
 		contentType != "application/json" &&
 		!strings.HasPrefix(contentType, "application/json;") &&
 		!isMediaTypeJson(contentType) &&
+		!isMediaTypeXML(contentType) &&
 		contentType != "text/plain" &&
 		!strings.HasPrefix(contentType, "text/plain;") &&
 		contentType != "text/html" &&
@@ -14,6 +14,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"encoding/xml"
 	"fmt"
 	"io"
 	"net/http"
@@ -29,8 +30,16 @@ type RequestOptions interface {
 	GetHeader() (map[string]string, error)
 }
 
+// CookieRequestOptions are RequestOptions with cookie params, implemented by the options of generated clients.
+type CookieRequestOptions interface {
+	RequestOptions
+	GetCookies() (map[string]any, error)
+}
+
 // RequestOptionsParameters holds the parameters for creating a request.
 type RequestOptionsParameters struct {
+	// OperationID is the ID of the operation, passed to client middlewares.
+	OperationID   string
 	Options       RequestOptions
 	RequestURL    string
 	Method        string
@@ -53,20 +62,48 @@ type Response struct {
 	Raw        *http.Response
 }
 
+// StreamResponse is a response whose body is streamed instead of read upfront.
+// The caller owns the body and must close it.
+type StreamResponse struct {
+	Body       io.ReadCloser
+	StatusCode int
+	Headers    http.Header
+	Raw        *http.Response
+}
+
+// Close closes the response body.
+func (r *StreamResponse) Close() error {
+	if r == nil || r.Body == nil {
+		return nil
+	}
+	return r.Body.Close()
+}
+
 type APIClient interface {
 	GetBaseURL() string
 	CreateRequest(ctx context.Context, params RequestOptionsParameters, reqEditors ...RequestEditorFn) (*http.Request, error)
 	ExecuteRequest(ctx context.Context, req *http.Request, operationPath string) (*Response, error)
 }
 
+// StreamingAPIClient is an APIClient executing requests without reading the response body upfront.
+// Generated clients require it for operations streaming their response.
+type StreamingAPIClient interface {
+	APIClient
+	ExecuteRequestStream(ctx context.Context, req *http.Request, operationPath string) (*StreamResponse, error)
+}
+
 // Client is a client for making API requests.
 // BaseURL is the base URL for the API.
 // httpClient is the HTTP client to use for making requests.
 // requestEditors is a list of callbacks for modifying requests which are generated before sending over the network.
+// retryPolicy is the policy for retrying failed requests, requests are not retried when nil.
+// middlewares is a list of middlewares wrapping the execution of requests.
 type Client struct {
 	baseURL        string
 	httpClient     HttpRequestDoer
 	requestEditors []RequestEditorFn
+	retryPolicy    *RetryPolicy
+	middlewares    []ClientMiddleware
 }
 
 // GetBaseURL returns the base URL of the API client.
@@ -81,6 +118,9 @@ func (c *Client) CreateRequest(ctx context.Context, params RequestOptionsParamet
 	if err != nil {
 		return nil, fmt.Errorf("error creating request: %w", err)
 	}
+	if params.OperationID != "" {
+		req = req.WithContext(withOperationID(req.Context(), params.OperationID))
+	}
 
 	if err = c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, fmt.Errorf("error applying request editors: %w", err)
@@ -89,10 +129,9 @@ func (c *Client) CreateRequest(ctx context.Context, params RequestOptionsParamet
 	return req, nil
 }
 
-// ExecuteRequest sends the HTTP request and returns the response.
-// It records the HTTP call with latency if an HTTPCallRecorder is set.
+// ExecuteRequest sends the HTTP request through the client middlewares and returns the response.
 func (c *Client) ExecuteRequest(ctx context.Context, req *http.Request, operationPath string) (*Response, error) {
-	resp, err := c.httpClient.Do(ctx, req)
+	resp, err := c.do(ctx, req, operationPath)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %w", err)
 	}
@@ -119,6 +158,45 @@ func (c *Client) ExecuteRequest(ctx context.Context, req *http.Request, operatio
 	}, nil
 }
 
+// ExecuteRequestStream sends the HTTP request through the client middlewares and returns the response without reading its body.
+// The caller must close the body of the returned response.
+func (c *Client) ExecuteRequestStream(ctx context.Context, req *http.Request, operationPath string) (*StreamResponse, error) {
+	resp, err := c.do(ctx, req, operationPath)
+	if err != nil {
+		return nil, fmt.Errorf("error sending request: %w", err)
+	}
+
+	if resp == nil {
+		return nil, nil
+	}
+
+	body := resp.Body
+	if body == nil {
+		body = http.NoBody
+	}
+
+	return &StreamResponse{
+		Body:       body,
+		StatusCode: resp.StatusCode,
+		Headers:    resp.Header,
+		Raw:        resp,
+	}, nil
+}
+
+// do sends the request through the middlewares, retrying it according to the retry policy.
+func (c *Client) do(ctx context.Context, req *http.Request, operationPath string) (*http.Response, error) {
+	doer := c.httpClient
+	if c.retryPolicy != nil {
+		doer = doerFunc(func(ctx context.Context, req *http.Request) (*http.Response, error) {
+			return c.retryPolicy.do(ctx, c.httpClient, req)
+		})
+	}
+
+	op := operationInfo(req, operationPath)
+	ctx = context.WithValue(ctx, operationInfoKey{}, op)
+	return chainMiddlewares(doer, op, c.middlewares).Do(ctx, req)
+}
+
 // applyEditors applies all the request editors to the request.
 func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
 	for _, r := range c.requestEditors {
@@ -181,6 +259,7 @@ func createRequest(ctx context.Context, params RequestOptionsParameters) (*http.
 		pathParams  map[string]any
 		queryParams map[string]any
 		headers     map[string]string
+		cookies     map[string]any
 		payload     any
 	)
 
@@ -200,6 +279,13 @@ func createRequest(ctx context.Context, params RequestOptionsParameters) (*http.
 			return nil, err
 		}
 
+		if cookieOptions, ok := options.(CookieRequestOptions); ok {
+			cookies, err = cookieOptions.GetCookies()
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		payload = options.GetBody()
 	}
 
@@ -246,20 +332,33 @@ func createRequest(ctx context.Context, params RequestOptionsParameters) (*http.
 	}
 
 	var (
-		bodyBytes  []byte
-		bodyReader io.Reader
+		bodyBytes     []byte
+		bodyReader    io.Reader
+		multipartBody *MultipartBody
 	)
 
 	// Encode payload according to decided contentType
 	if payload != nil {
 		ctLower := strings.ToLower(strings.TrimSpace(contentType))
 		switch {
+		case strings.HasPrefix(ctLower, "multipart/"):
+			multipartBody, err = NewMultipartBody(payload, params.BodyEncoding)
+			if err != nil {
+				return nil, fmt.Errorf("error encoding multipart body: %w", err)
+			}
+			contentType = multipartBody.ContentType(contentType)
+			bodyReader = multipartBody.Reader()
 		case strings.HasPrefix(ctLower, "application/x-www-form-urlencoded"):
 			encodedPayload, err := EncodeFormFields(payload, params.BodyEncoding)
 			if err != nil {
 				return nil, fmt.Errorf("error encoding form values: %w", err)
 			}
 			bodyBytes = []byte(encodedPayload)
+		case isXMLContentType(ctLower):
+			bodyBytes, err = xml.Marshal(payload)
+			if err != nil {
+				return nil, fmt.Errorf("error encoding xml body: %w", err)
+			}
 		default:
 			// Default: treat as JSON
 			bodyBytes, err = json.Marshal(payload)
@@ -280,6 +379,16 @@ func createRequest(ctx context.Context, params RequestOptionsParameters) (*http.
 
 	httpHeaders.Set("Content-Type", contentType)
 	req.Header = httpHeaders
+	if err = addCookies(req, cookies); err != nil {
+		return nil, err
+	}
+
+	// Multipart bodies are streamed, with an unknown length
+	if multipartBody != nil && multipartBody.Rewindable() {
+		req.GetBody = func() (io.ReadCloser, error) {
+			return multipartBody.Reader(), nil
+		}
+	}
 
 	if bodyBytes != nil {
 		req.ContentLength = int64(len(bodyBytes))
@@ -295,6 +404,98 @@ func createRequest(ctx context.Context, params RequestOptionsParameters) (*http.
 	return req, nil
 }
 
+// addCookies adds the cookie params to the request, sorted by name.
+// Values are serialized in the form style without explode, the default for cookies:
+// arrays and objects are comma separated. The Cookie header is written as is,
+// so values with characters not allowed in cookies are rejected rather than quoted or dropped.
+func addCookies(req *http.Request, cookies map[string]any) error {
+	names := make([]string, 0, len(cookies))
+	for name, value := range cookies {
+		if value == nil {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	pairs := make([]string, 0, len(names)+1)
+	if existing := req.Header.Get("Cookie"); existing != "" {
+		pairs = append(pairs, existing)
+	}
+	for _, name := range names {
+		value, err := cookieValue(cookies[name])
+		if err != nil {
+			return fmt.Errorf("%w %q: %w", ErrInvalidCookie, name, err)
+		}
+		if err = (&http.Cookie{Name: name, Value: value}).Valid(); err != nil {
+			return fmt.Errorf("%w %q: %w", ErrInvalidCookie, name, err)
+		}
+		// Valid allows spaces, which are only sent quoted
+		if strings.Contains(value, " ") {
+			return fmt.Errorf("%w %q: invalid space in value", ErrInvalidCookie, name)
+		}
+		pairs = append(pairs, name+"="+value)
+	}
+	if len(pairs) > 0 {
+		req.Header.Set("Cookie", strings.Join(pairs, "; "))
+	}
+	return nil
+}
+
+// cookieValue serializes a cookie param value decoded from JSON.
+func cookieValue(v any) (string, error) {
+	switch t := v.(type) {
+	case []any:
+		values := make([]string, len(t))
+		for i, e := range t {
+			s, err := cookieScalar(e)
+			if err != nil {
+				return "", err
+			}
+			values[i] = s
+		}
+		return strings.Join(values, ","), nil
+	case map[string]any:
+		keys := make([]string, 0, len(t))
+		for k := range t {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		values := make([]string, 0, 2*len(keys))
+		for _, k := range keys {
+			s, err := cookieScalar(t[k])
+			if err != nil {
+				return "", err
+			}
+			values = append(values, k, s)
+		}
+		return strings.Join(values, ","), nil
+	default:
+		return cookieScalar(v)
+	}
+}
+
+func cookieScalar(v any) (string, error) {
+	switch t := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return t, nil
+	case bool:
+		return strconv.FormatBool(t), nil
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64), nil
+	case float32:
+		return strconv.FormatFloat(float64(t), 'f', -1, 32), nil
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return fmt.Sprintf("%d", t), nil
+	case fmt.Stringer:
+		return t.String(), nil
+	default:
+		return "", fmt.Errorf("unsupported value of type %T", v)
+	}
+}
+
 func replacePathPlaceholders(reqURL string, pathParams map[string]any) string {
 	for k, v := range pathParams {
 		reqURL = strings.ReplaceAll(reqURL, fmt.Sprintf("{%s}", k), fmt.Sprintf("%v", v))
@@ -302,4 +503,11 @@ func replacePathPlaceholders(reqURL string, pathParams map[string]any) string {
 	return reqURL
 }
 
-var _ APIClient = (*Client)(nil)
+var _ StreamingAPIClient = (*Client)(nil)
+
+// isXMLContentType reports whether the lower-cased content type is an XML media type.
+func isXMLContentType(contentType string) bool {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	mediaType = strings.TrimSpace(mediaType)
+	return mediaType == "application/xml" || mediaType == "text/xml" || strings.HasSuffix(mediaType, "+xml")
+}
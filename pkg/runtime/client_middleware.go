@@ -0,0 +1,90 @@
+// Copyright 2025 DoorDash, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"net/http"
+)
+
+// OperationInfo describes the API operation a request is sent for.
+type OperationInfo struct {
+	// ID is the operation ID, as named in the generated client.
+	ID string
+	// Method is the HTTP method of the operation.
+	Method string
+	// Path is the path template of the operation, e.g. /pets/{id}.
+	Path string
+}
+
+// ClientMiddleware intercepts the requests sent by the Client, e.g. for metrics, logging, tracing or caching.
+type ClientMiddleware interface {
+	// RoundTrip sends req by calling next, and returns its response.
+	// The response body is read by the client once RoundTrip returns, unless the response is streamed.
+	RoundTrip(ctx context.Context, op OperationInfo, req *http.Request, next HttpRequestDoer) (*http.Response, error)
+}
+
+// ClientMiddlewareFunc is a function implementing ClientMiddleware.
+type ClientMiddlewareFunc func(ctx context.Context, op OperationInfo, req *http.Request, next HttpRequestDoer) (*http.Response, error)
+
+// RoundTrip calls f.
+func (f ClientMiddlewareFunc) RoundTrip(ctx context.Context, op OperationInfo, req *http.Request, next HttpRequestDoer) (*http.Response, error) {
+	return f(ctx, op, req, next)
+}
+
+// WithClientMiddleware adds middlewares wrapping the execution of requests.
+// The first middleware is the outermost one, and all of them wrap the retries of the retry policy.
+func WithClientMiddleware(middlewares ...ClientMiddleware) APIClientOption {
+	return func(c *Client) error {
+		c.middlewares = append(c.middlewares, middlewares...)
+		return nil
+	}
+}
+
+// OperationInfoFromContext returns the operation a request is sent for, from the context given to middlewares
+// and to the HttpRequestDoer.
+func OperationInfoFromContext(ctx context.Context) (OperationInfo, bool) {
+	op, ok := ctx.Value(operationInfoKey{}).(OperationInfo)
+	return op, ok
+}
+
+type operationInfoKey struct{}
+
+type operationIDKey struct{}
+
+// withOperationID sets the ID of the operation a request is created for.
+func withOperationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, operationIDKey{}, id)
+}
+
+// operationInfo returns the operation req is sent for.
+func operationInfo(req *http.Request, operationPath string) OperationInfo {
+	id, _ := req.Context().Value(operationIDKey{}).(string)
+	return OperationInfo{ID: id, Method: req.Method, Path: operationPath}
+}
+
+// doerFunc is a function implementing HttpRequestDoer.
+type doerFunc func(ctx context.Context, req *http.Request) (*http.Response, error)
+
+func (f doerFunc) Do(ctx context.Context, req *http.Request) (*http.Response, error) {
+	return f(ctx, req)
+}
+
+// chainMiddlewares returns a doer calling the middlewares in order, then doer.
+func chainMiddlewares(doer HttpRequestDoer, op OperationInfo, middlewares []ClientMiddleware) HttpRequestDoer {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		mw, next := middlewares[i], doer
+		doer = doerFunc(func(ctx context.Context, req *http.Request) (*http.Response, error) {
+			return mw.RoundTrip(ctx, op, req, next)
+		})
+	}
+	return doer
+}
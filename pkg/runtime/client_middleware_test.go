@@ -0,0 +1,141 @@
+// Copyright 2025 DoorDash, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithClientMiddleware(t *testing.T) {
+	newRequest := func(t *testing.T, client *Client) *http.Request {
+		t.Helper()
+		req, err := client.CreateRequest(context.Background(), RequestOptionsParameters{
+			OperationID: "GetPet",
+			RequestURL:  "https://example.com/pets/1",
+			Method:      http.MethodGet,
+		})
+		require.NoError(t, err)
+		return req
+	}
+
+	t.Run("middlewares wrap the request in order", func(t *testing.T) {
+		var calls []string
+		recorder := func(name string) ClientMiddleware {
+			return ClientMiddlewareFunc(func(ctx context.Context, op OperationInfo, req *http.Request, next HttpRequestDoer) (*http.Response, error) {
+				calls = append(calls, name+" "+op.ID+" "+op.Method+" "+op.Path)
+				resp, err := next.Do(ctx, req)
+				calls = append(calls, name+" done")
+				return resp, err
+			})
+		}
+
+		var doerOp OperationInfo
+		doer := doerFunc(func(ctx context.Context, req *http.Request) (*http.Response, error) {
+			doerOp, _ = OperationInfoFromContext(ctx)
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("ok"))}, nil
+		})
+		client, err := NewAPIClient("https://example.com",
+			WithHTTPClient(doer),
+			WithClientMiddleware(recorder("first"), recorder("second")))
+		require.NoError(t, err)
+
+		resp, err := client.ExecuteRequest(context.Background(), newRequest(t, client), "/pets/{id}")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("ok"), resp.Content)
+		assert.Equal(t, []string{
+			"first GetPet GET /pets/{id}",
+			"second GetPet GET /pets/{id}",
+			"second done",
+			"first done",
+		}, calls)
+		assert.Equal(t, OperationInfo{ID: "GetPet", Method: http.MethodGet, Path: "/pets/{id}"}, doerOp)
+	})
+
+	t.Run("middlewares observe responses", func(t *testing.T) {
+		var status int
+		metrics := ClientMiddlewareFunc(func(ctx context.Context, op OperationInfo, req *http.Request, next HttpRequestDoer) (*http.Response, error) {
+			resp, err := next.Do(ctx, req)
+			if resp != nil {
+				status = resp.StatusCode
+			}
+			return resp, err
+		})
+
+		doer := &MockHttpRequestDoer{response: &http.Response{StatusCode: http.StatusNotFound, Body: http.NoBody}}
+		client, err := NewAPIClient("https://example.com", WithHTTPClient(doer), WithClientMiddleware(metrics))
+		require.NoError(t, err)
+
+		_, err = client.ExecuteRequestStream(context.Background(), newRequest(t, client), "/pets/{id}")
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, status)
+	})
+
+	t.Run("middlewares can short-circuit", func(t *testing.T) {
+		errCached := errors.New("served from cache")
+		cache := ClientMiddlewareFunc(func(ctx context.Context, op OperationInfo, req *http.Request, next HttpRequestDoer) (*http.Response, error) {
+			return nil, errCached
+		})
+
+		doer := &scriptedDoer{results: []retryTestResult{{status: http.StatusOK}}}
+		client, err := NewAPIClient("https://example.com", WithHTTPClient(doer), WithClientMiddleware(cache))
+		require.NoError(t, err)
+
+		_, err = client.ExecuteRequest(context.Background(), newRequest(t, client), "/pets/{id}")
+		require.ErrorIs(t, err, errCached)
+		assert.Empty(t, doer.requests)
+	})
+
+	t.Run("middlewares wrap retries", func(t *testing.T) {
+		calls := 0
+		counter := ClientMiddlewareFunc(func(ctx context.Context, op OperationInfo, req *http.Request, next HttpRequestDoer) (*http.Response, error) {
+			calls++
+			return next.Do(ctx, req)
+		})
+
+		doer := &scriptedDoer{results: []retryTestResult{{status: http.StatusServiceUnavailable}, {status: http.StatusOK}}}
+		client, err := NewAPIClient("https://example.com",
+			WithHTTPClient(doer),
+			WithRetryPolicy(testRetryPolicy()),
+			WithClientMiddleware(counter))
+		require.NoError(t, err)
+
+		resp, err := client.ExecuteRequest(context.Background(), newRequest(t, client), "/pets/{id}")
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, 1, calls)
+		assert.Len(t, doer.requests, 2)
+	})
+
+	t.Run("requests without operation ID", func(t *testing.T) {
+		var got OperationInfo
+		mw := ClientMiddlewareFunc(func(ctx context.Context, op OperationInfo, req *http.Request, next HttpRequestDoer) (*http.Response, error) {
+			got = op
+			return next.Do(ctx, req)
+		})
+
+		doer := &MockHttpRequestDoer{response: &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}}
+		client, err := NewAPIClient("https://example.com", WithHTTPClient(doer), WithClientMiddleware(mw))
+		require.NoError(t, err)
+
+		req, _ := http.NewRequest(http.MethodDelete, "https://example.com/pets/1", nil)
+		_, err = client.ExecuteRequest(context.Background(), req, "/pets/{id}")
+		require.NoError(t, err)
+		assert.Equal(t, OperationInfo{Method: http.MethodDelete, Path: "/pets/{id}"}, got)
+	})
+}
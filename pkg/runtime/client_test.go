@@ -12,9 +12,11 @@ package runtime
 
 import (
 	"context"
+	"encoding/xml"
 	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 
@@ -27,12 +29,14 @@ type mockRequestOptions struct {
 	query      map[string]any
 	body       any
 	header     map[string]string
+	cookies    map[string]any
 }
 
 func (m mockRequestOptions) GetPathParams() (map[string]any, error) { return m.pathParams, nil }
 func (m mockRequestOptions) GetQuery() (map[string]any, error)      { return m.query, nil }
 func (m mockRequestOptions) GetBody() any                           { return m.body }
 func (m mockRequestOptions) GetHeader() (map[string]string, error)  { return m.header, nil }
+func (m mockRequestOptions) GetCookies() (map[string]any, error)    { return m.cookies, nil }
 
 type MockHttpRequestDoer struct {
 	response *http.Response
@@ -60,6 +64,7 @@ func TestClient_CreateRequest(t *testing.T) {
 		expectedURL         string
 		expectedError       bool
 		expectedContentType string
+		expectedBody        string
 	}{
 		{
 			name: "creates GET request successfully",
@@ -141,6 +146,25 @@ func TestClient_CreateRequest(t *testing.T) {
 			expectedError:       false,
 			expectedContentType: "application/x-www-form-urlencoded+foo",
 		},
+		{
+			name: "creates POST request with xml body",
+			params: RequestOptionsParameters{
+				Options: mockRequestOptions{
+					body: struct {
+						XMLName xml.Name `xml:"user"`
+						Name    string   `xml:"name,attr"`
+					}{Name: "test"},
+				},
+				RequestURL:  "https://api.example.com/users",
+				Method:      "POST",
+				ContentType: "application/xml",
+			},
+			expectedMethod:      "POST",
+			expectedURL:         "https://api.example.com/users",
+			expectedError:       false,
+			expectedContentType: "application/xml",
+			expectedBody:        `<user name="test"></user>`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -157,6 +181,11 @@ func TestClient_CreateRequest(t *testing.T) {
 			assert.Equal(t, tt.expectedMethod, req.Method)
 			assert.Equal(t, tt.expectedURL, req.URL.String())
 			assert.Equal(t, tt.expectedContentType, req.Header.Get("Content-Type"))
+			if tt.expectedBody != "" {
+				body, err := io.ReadAll(req.Body)
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedBody, string(body))
+			}
 		})
 	}
 }
@@ -177,6 +206,113 @@ func TestClient_CreateRequest_no_options_passed(t *testing.T) {
 	assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
 }
 
+func TestClient_CreateRequest_cookies(t *testing.T) {
+	params := RequestOptionsParameters{
+		Options: mockRequestOptions{
+			cookies: map[string]any{"session": "abc", "page": 2, "unset": nil},
+		},
+		RequestURL: "https://api.example.com/users",
+		Method:     "GET",
+	}
+	client := &Client{}
+
+	req, err := client.CreateRequest(context.Background(), params)
+	require.NoError(t, err)
+
+	assert.Equal(t, "page=2; session=abc", req.Header.Get("Cookie"))
+}
+
+// legacyRequestOptions are the options of clients generated before cookie params, without GetCookies.
+type legacyRequestOptions struct{}
+
+func (legacyRequestOptions) GetPathParams() (map[string]any, error) { return nil, nil }
+func (legacyRequestOptions) GetQuery() (map[string]any, error)      { return nil, nil }
+func (legacyRequestOptions) GetBody() any                           { return nil }
+func (legacyRequestOptions) GetHeader() (map[string]string, error)  { return nil, nil }
+
+func TestClient_CreateRequest_withoutCookies(t *testing.T) {
+	client := &Client{}
+	req, err := client.CreateRequest(context.Background(), RequestOptionsParameters{
+		Options:    legacyRequestOptions{},
+		RequestURL: "https://api.example.com/users",
+		Method:     http.MethodGet,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, req.Header.Get("Cookie"))
+}
+
+func TestAddCookies(t *testing.T) {
+	t.Run("form style values", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Cookie", "existing=1")
+
+		cookies, err := AsMap[any](struct {
+			Count  int               `json:"count"`
+			Ratio  float64           `json:"ratio"`
+			Flag   bool              `json:"flag"`
+			IDs    []string          `json:"ids"`
+			Filter map[string]string `json:"filter"`
+		}{
+			Count:  1000000,
+			Ratio:  0.5,
+			Flag:   true,
+			IDs:    []string{"x", "y"},
+			Filter: map[string]string{"role": "admin", "first": "a"},
+		})
+		require.NoError(t, err)
+		require.NoError(t, addCookies(req, cookies))
+
+		assert.Equal(t, "existing=1; count=1000000; filter=first,a,role,admin; flag=true; ids=x,y; ratio=0.5", req.Header.Get("Cookie"))
+	})
+
+	t.Run("invalid values", func(t *testing.T) {
+		tests := map[string]map[string]any{
+			"semicolon":    {"session": "a;b"},
+			"space":        {"session": "a b"},
+			"quote":        {"session": `a"b`},
+			"non ascii":    {"session": "é"},
+			"name":         {"a b": "x"},
+			"nested array": {"ids": []any{[]any{"x"}}},
+		}
+		for name, cookies := range tests {
+			t.Run(name, func(t *testing.T) {
+				req := httptest.NewRequest(http.MethodGet, "/", nil)
+				require.ErrorIs(t, addCookies(req, cookies), ErrInvalidCookie)
+			})
+		}
+	})
+}
+
+func TestClient_CreateRequest_multipart(t *testing.T) {
+	var file File
+	file.InitFromBytes([]byte("data"), "data.bin")
+
+	req, err := createRequest(context.Background(), RequestOptionsParameters{
+		Options: mockRequestOptions{
+			body: &multipartTestBody{File: file, Count: ptr(1)},
+		},
+		RequestURL:  "https://api.example.com/upload",
+		Method:      http.MethodPost,
+		ContentType: "multipart/form-data",
+		BodyEncoding: map[string]FieldEncoding{
+			"file": {ContentType: "application/pdf"},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), req.ContentLength)
+	require.NotNil(t, req.GetBody)
+
+	parts := readMultipartParts(t, req.Header.Get("Content-Type"), req.Body)
+	assert.Equal(t, []multipartTestPart{
+		{name: "file", filename: "data.bin", contentType: "application/pdf", content: "data"},
+		{name: "count", content: "1"},
+	}, parts)
+
+	body, err := req.GetBody()
+	require.NoError(t, err)
+	assert.Len(t, readMultipartParts(t, req.Header.Get("Content-Type"), body), 2)
+}
+
 func TestClient_ExecuteRequest(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -226,6 +362,61 @@ func TestClient_ExecuteRequest(t *testing.T) {
 	}
 }
 
+func TestClient_ExecuteRequestStream(t *testing.T) {
+	t.Run("body is not read", func(t *testing.T) {
+		body := &trackingReadCloser{Reader: strings.NewReader("binary data")}
+		client := &Client{
+			httpClient: &MockHttpRequestDoer{
+				response: &http.Response{StatusCode: http.StatusOK, Header: http.Header{"X-Id": {"1"}}, Body: body},
+			},
+		}
+
+		req, _ := http.NewRequest(http.MethodGet, "http://example.com", nil)
+		resp, err := client.ExecuteRequestStream(context.Background(), req, "/files/{id}")
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "1", resp.Headers.Get("X-Id"))
+		assert.False(t, body.closed)
+
+		data, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "binary data", string(data))
+		require.NoError(t, resp.Close())
+		assert.True(t, body.closed)
+	})
+
+	t.Run("missing body", func(t *testing.T) {
+		client := &Client{
+			httpClient: &MockHttpRequestDoer{response: &http.Response{StatusCode: http.StatusNoContent}},
+		}
+
+		req, _ := http.NewRequest(http.MethodGet, "http://example.com", nil)
+		resp, err := client.ExecuteRequestStream(context.Background(), req, "/files/{id}")
+		require.NoError(t, err)
+		assert.Equal(t, http.NoBody, resp.Body)
+	})
+
+	t.Run("failed request", func(t *testing.T) {
+		client := &Client{
+			httpClient: &MockHttpRequestDoer{err: fmt.Errorf("network error")},
+		}
+
+		req, _ := http.NewRequest(http.MethodGet, "http://example.com", nil)
+		_, err := client.ExecuteRequestStream(context.Background(), req, "/files/{id}")
+		assert.Error(t, err)
+	})
+}
+
+type trackingReadCloser struct {
+	io.Reader
+	closed bool
+}
+
+func (r *trackingReadCloser) Close() error {
+	r.closed = true
+	return nil
+}
+
 func TestNewAPIClient(t *testing.T) {
 	tests := []struct {
 		name        string
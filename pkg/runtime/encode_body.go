@@ -24,6 +24,9 @@ type FieldEncoding struct {
 	Style       string
 	Explode     *bool
 	ContentType string
+
+	// Headers are sent with the part of the field in multipart bodies.
+	Headers map[string]string
 }
 
 // EncodeFormFields encodes the given data into a URL-encoded form string.
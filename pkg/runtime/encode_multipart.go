@@ -0,0 +1,358 @@
+// Copyright 2025 DoorDash, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package runtime
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/textproto"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// additionalPropsField is the field holding the additional properties of generated types.
+const additionalPropsField = "AdditionalProperties"
+
+var (
+	fileType     = reflect.TypeOf(File{})
+	quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+)
+
+// MultipartBody is a multipart request body.
+// Its parts are collected upfront, but written as the body is read, so files are streamed instead of buffered.
+type MultipartBody struct {
+	boundary string
+	parts    []multipartPart
+}
+
+// multipartPart is a single part of a multipart body, holding either a value or a file.
+type multipartPart struct {
+	header textproto.MIMEHeader
+	data   []byte
+	file   *File
+}
+
+// NewMultipartBody collects the parts of a multipart body from the fields of data, a struct or a map.
+// Files become file parts, primitive values text parts, arrays of primitives one part per item,
+// and objects JSON parts. The encoding sets the content type and the headers of the part of a field.
+func NewMultipartBody(data any, encoding map[string]FieldEncoding) (*MultipartBody, error) {
+	body := &MultipartBody{boundary: multipart.NewWriter(io.Discard).Boundary()}
+
+	fields, err := multipartFields(reflect.ValueOf(data))
+	if err != nil {
+		return nil, err
+	}
+	for _, field := range fields {
+		if err := body.addValue(field.name, field.value, encoding[field.name]); err != nil {
+			return nil, fmt.Errorf("error encoding multipart field %s: %w", field.name, err)
+		}
+	}
+	return body, nil
+}
+
+// ContentType returns the content type of the body for the given multipart media type, including the boundary.
+// Defaults to multipart/form-data.
+func (b *MultipartBody) ContentType(mediaType string) string {
+	if parsed, _, err := mime.ParseMediaType(mediaType); err == nil && strings.HasPrefix(parsed, "multipart/") {
+		mediaType = parsed
+	} else {
+		mediaType = "multipart/form-data"
+	}
+	return mime.FormatMediaType(mediaType, map[string]string{"boundary": b.boundary})
+}
+
+// Reader returns a reader of the encoded body. Every call returns a new reader of the whole body,
+// as long as the body is Rewindable.
+func (b *MultipartBody) Reader() io.ReadCloser {
+	return &multipartReader{body: b}
+}
+
+// Rewindable returns true if the body can be read more than once, i.e. none of its files is read from a one-shot reader.
+func (b *MultipartBody) Rewindable() bool {
+	for _, part := range b.parts {
+		if part.file != nil && !part.file.Rewindable() {
+			return false
+		}
+	}
+	return true
+}
+
+// write writes the parts to w, closing it with the error encountered, if any.
+func (b *MultipartBody) write(w *io.PipeWriter) {
+	mw := multipart.NewWriter(w)
+	if err := mw.SetBoundary(b.boundary); err != nil {
+		_ = w.CloseWithError(err)
+		return
+	}
+
+	for _, part := range b.parts {
+		pw, err := mw.CreatePart(part.header)
+		if err != nil {
+			_ = w.CloseWithError(err)
+			return
+		}
+		if part.file == nil {
+			_, err = pw.Write(part.data)
+		} else {
+			err = copyFile(pw, part.file)
+		}
+		if err != nil {
+			_ = w.CloseWithError(err)
+			return
+		}
+	}
+
+	_ = w.CloseWithError(mw.Close())
+}
+
+func copyFile(w io.Writer, file *File) error {
+	r, err := file.Reader()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = r.Close() }()
+	_, err = io.Copy(w, r)
+	return err
+}
+
+func (b *MultipartBody) addValue(name string, v reflect.Value, enc FieldEncoding) error {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+
+	if v.Type() == fileType {
+		file := v.Interface().(File)
+		b.addFile(name, &file, enc)
+		return nil
+	}
+
+	if (v.Kind() == reflect.Slice || v.Kind() == reflect.Array) && isFileType(v.Type().Elem()) {
+		for i := range v.Len() {
+			if err := b.addValue(name, v.Index(i), enc); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	data, err := json.Marshal(v.Interface())
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case bytes.HasPrefix(data, []byte("[")):
+		var items []json.RawMessage
+		if err := json.Unmarshal(data, &items); err != nil {
+			return err
+		}
+		// Arrays of primitives are sent as one part per item, others as JSON
+		if !containsObjects(items) {
+			for _, item := range items {
+				b.addText(name, item, enc)
+			}
+			return nil
+		}
+		b.addJSON(name, data, enc)
+	case bytes.HasPrefix(data, []byte("{")):
+		b.addJSON(name, data, enc)
+	default:
+		b.addText(name, data, enc)
+	}
+	return nil
+}
+
+func (b *MultipartBody) addFile(name string, file *File, enc FieldEncoding) {
+	filename := file.Filename()
+	if filename == "" {
+		filename = name
+	}
+	header := partHeader(enc, "application/octet-stream")
+	header.Set("Content-Disposition",
+		fmt.Sprintf(`form-data; name="%s"; filename="%s"`, quoteEscaper.Replace(name), quoteEscaper.Replace(filename)))
+	b.parts = append(b.parts, multipartPart{header: header, file: file})
+}
+
+func (b *MultipartBody) addJSON(name string, data []byte, enc FieldEncoding) {
+	header := partHeader(enc, "application/json")
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"`, quoteEscaper.Replace(name)))
+	b.parts = append(b.parts, multipartPart{header: header, data: data})
+}
+
+// addText adds a primitive JSON value as a text part, strings unquoted, null skipped.
+func (b *MultipartBody) addText(name string, value json.RawMessage, enc FieldEncoding) {
+	if string(value) == "null" {
+		return
+	}
+	data := []byte(value)
+	var s string
+	if err := json.Unmarshal(value, &s); err == nil {
+		data = []byte(s)
+	}
+	header := partHeader(enc, "")
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"`, quoteEscaper.Replace(name)))
+	b.parts = append(b.parts, multipartPart{header: header, data: data})
+}
+
+// partHeader returns the headers of a part from its encoding.
+// The encoding content type may list several types, the first one is used.
+func partHeader(enc FieldEncoding, defaultContentType string) textproto.MIMEHeader {
+	header := textproto.MIMEHeader{}
+	for k, v := range enc.Headers {
+		header.Set(k, v)
+	}
+
+	contentType := defaultContentType
+	if enc.ContentType != "" {
+		contentType, _, _ = strings.Cut(enc.ContentType, ",")
+		contentType = strings.TrimSpace(contentType)
+	}
+	if contentType != "" {
+		header.Set("Content-Type", contentType)
+	}
+	return header
+}
+
+// multipartField is a named value of the multipart body.
+type multipartField struct {
+	name  string
+	value reflect.Value
+}
+
+// multipartFields returns the fields of a struct by their JSON names, or the entries of a map sorted by key.
+// Other values are converted through their JSON representation.
+func multipartFields(v reflect.Value) ([]multipartField, error) {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil, nil
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return nil, nil
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		var res []multipartField
+		t := v.Type()
+		for i := range t.NumField() {
+			sf := t.Field(i)
+			fv := v.Field(i)
+			if !sf.IsExported() {
+				continue
+			}
+
+			tag := sf.Tag.Get("json")
+			name, opts, _ := strings.Cut(tag, ",")
+			if tag == "-" {
+				// Additional properties of generated types are marshaled by a custom MarshalJSON
+				if sf.Name == additionalPropsField && fv.Kind() == reflect.Map {
+					entries, err := multipartFields(fv)
+					if err != nil {
+						return nil, err
+					}
+					res = append(res, entries...)
+				}
+				continue
+			}
+			if sf.Anonymous && name == "" {
+				embedded, err := multipartFields(fv)
+				if err != nil {
+					return nil, err
+				}
+				res = append(res, embedded...)
+				continue
+			}
+			if name == "" {
+				name = sf.Name
+			}
+			if strings.Contains(opts, "omitempty") && fv.IsZero() {
+				continue
+			}
+			res = append(res, multipartField{name: name, value: fv})
+		}
+		return res, nil
+
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("unsupported multipart body type %s", v.Type())
+		}
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+		res := make([]multipartField, len(keys))
+		for i, k := range keys {
+			res[i] = multipartField{name: k.String(), value: v.MapIndex(k)}
+		}
+		return res, nil
+	}
+
+	data, err := json.Marshal(v.Interface())
+	if err != nil {
+		return nil, err
+	}
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, fmt.Errorf("unsupported multipart body type %s", v.Type())
+	}
+	return multipartFields(reflect.ValueOf(obj))
+}
+
+func isFileType(t reflect.Type) bool {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t == fileType
+}
+
+func containsObjects(items []json.RawMessage) bool {
+	for _, item := range items {
+		if bytes.HasPrefix(item, []byte("{")) || bytes.HasPrefix(item, []byte("[")) {
+			return true
+		}
+	}
+	return false
+}
+
+// multipartReader writes the body through a pipe, started on the first read,
+// so a request that is never sent doesn't leave a writer behind.
+type multipartReader struct {
+	body *MultipartBody
+	once sync.Once
+	pr   *io.PipeReader
+}
+
+func (r *multipartReader) start() {
+	pr, pw := io.Pipe()
+	r.pr = pr
+	go r.body.write(pw)
+}
+
+func (r *multipartReader) Read(p []byte) (int, error) {
+	r.once.Do(r.start)
+	return r.pr.Read(p)
+}
+
+func (r *multipartReader) Close() error {
+	r.once.Do(func() {
+		r.pr, _ = io.Pipe()
+	})
+	return r.pr.Close()
+}
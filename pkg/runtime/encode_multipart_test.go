@@ -0,0 +1,144 @@
+// Copyright 2025 DoorDash, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package runtime
+
+import (
+	"io"
+	"mime"
+	"mime/multipart"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type multipartTestPart struct {
+	name        string
+	filename    string
+	contentType string
+	header      string
+	content     string
+}
+
+func readMultipartParts(t *testing.T, contentType string, body io.Reader) []multipartTestPart {
+	t.Helper()
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	require.NoError(t, err)
+	require.Equal(t, "multipart/form-data", mediaType)
+
+	var res []multipartTestPart
+	mr := multipart.NewReader(body, params["boundary"])
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		content, err := io.ReadAll(part)
+		require.NoError(t, err)
+		res = append(res, multipartTestPart{
+			name:        part.FormName(),
+			filename:    part.FileName(),
+			contentType: part.Header.Get("Content-Type"),
+			header:      part.Header.Get("X-Checksum"),
+			content:     string(content),
+		})
+	}
+	return res
+}
+
+type multipartTestMeta struct {
+	Owner string `json:"owner"`
+}
+
+type multipartTestBody struct {
+	File        File               `json:"file"`
+	Attachments []File             `json:"attachments,omitempty"`
+	Description *string            `json:"description,omitempty"`
+	Count       *int               `json:"count,omitempty"`
+	Tags        []string           `json:"tags,omitempty"`
+	Meta        *multipartTestMeta `json:"meta,omitempty"`
+	Skipped     *string            `json:"skipped,omitempty"`
+
+	AdditionalProperties map[string]string `json:"-"`
+}
+
+func TestMultipartBody(t *testing.T) {
+	var file, first, second File
+	file.InitFromBytes([]byte("PNG"), "image.png")
+	first.InitFromBytes([]byte("one"), "")
+	second.InitFromReader(strings.NewReader("two"), "two.txt")
+
+	body := multipartTestBody{
+		File:                 file,
+		Attachments:          []File{first, second},
+		Description:          ptr(`a "quoted" description`),
+		Count:                ptr(3),
+		Tags:                 []string{"a", "b"},
+		Meta:                 &multipartTestMeta{Owner: "me"},
+		AdditionalProperties: map[string]string{"extra": "value"},
+	}
+
+	mb, err := NewMultipartBody(&body, map[string]FieldEncoding{
+		"file": {ContentType: "image/png, image/jpeg", Headers: map[string]string{"X-Checksum": "abc"}},
+		"tags": {ContentType: "text/plain"},
+	})
+	require.NoError(t, err)
+	assert.False(t, mb.Rewindable())
+
+	parts := readMultipartParts(t, mb.ContentType(""), mb.Reader())
+	assert.Equal(t, []multipartTestPart{
+		{name: "file", filename: "image.png", contentType: "image/png", header: "abc", content: "PNG"},
+		{name: "attachments", filename: "attachments", contentType: "application/octet-stream", content: "one"},
+		{name: "attachments", filename: "two.txt", contentType: "application/octet-stream", content: "two"},
+		{name: "description", content: `a "quoted" description`},
+		{name: "count", content: "3"},
+		{name: "tags", contentType: "text/plain", content: "a"},
+		{name: "tags", contentType: "text/plain", content: "b"},
+		{name: "meta", contentType: "application/json", content: `{"owner":"me"}`},
+		{name: "extra", content: "value"},
+	}, parts)
+}
+
+func TestMultipartBody_map(t *testing.T) {
+	mb, err := NewMultipartBody(map[string]any{
+		"b":     []map[string]int{{"x": 1}},
+		"a":     true,
+		"empty": nil,
+	}, nil)
+	require.NoError(t, err)
+	assert.True(t, mb.Rewindable())
+
+	// a rewindable body can be read again
+	for range 2 {
+		parts := readMultipartParts(t, mb.ContentType("multipart/form-data"), mb.Reader())
+		assert.Equal(t, []multipartTestPart{
+			{name: "a", content: "true"},
+			{name: "b", contentType: "application/json", content: `[{"x":1}]`},
+		}, parts)
+	}
+}
+
+func TestMultipartBody_unsupported(t *testing.T) {
+	_, err := NewMultipartBody([]string{"a"}, nil)
+	assert.Error(t, err)
+}
+
+func TestMultipartBody_closeBeforeRead(t *testing.T) {
+	mb, err := NewMultipartBody(map[string]string{"a": "b"}, nil)
+	require.NoError(t, err)
+
+	r := mb.Reader()
+	require.NoError(t, r.Close())
+	_, err = r.Read(make([]byte, 1))
+	assert.ErrorIs(t, err, io.ErrClosedPipe)
+}
@@ -23,6 +23,14 @@ var (
 	ErrValidationEmail         = errors.New("email: failed to pass regex validation")
 	ErrFailedToUnmarshalAsAOrB = errors.New("failed to unmarshal as either A or B")
 	ErrMustBeMap               = errors.New("value must be map[string]any")
+	// ErrInvalidCookie is returned when a cookie param can't be sent as is.
+	ErrInvalidCookie = errors.New("invalid cookie")
+	// ErrStreamingNotSupported is returned by generated clients when the API client of a streamed operation
+	// doesn't implement StreamingAPIClient.
+	ErrStreamingNotSupported = errors.New("API client doesn't support streaming responses")
+	// ErrEventStreamNotFlushable is returned by EventSender when the response writer can't be flushed,
+	// so events would be buffered until the handler returns instead of being streamed.
+	ErrEventStreamNotFlushable = errors.New("event stream: response writer doesn't support flushing")
 )
 
 type ClientAPIErrorOption func(*ClientAPIError)
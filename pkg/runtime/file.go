@@ -19,12 +19,14 @@ import (
 
 type File struct {
 	multipart *multipart.FileHeader
+	reader    io.Reader
 	data      []byte
 	filename  string
 }
 
 func (file *File) InitFromMultipart(header *multipart.FileHeader) {
 	file.multipart = header
+	file.reader = nil
 	file.data = nil
 	file.filename = ""
 }
@@ -33,6 +35,16 @@ func (file *File) InitFromBytes(data []byte, filename string) {
 	file.data = data
 	file.filename = filename
 	file.multipart = nil
+	file.reader = nil
+}
+
+// InitFromReader initializes the file with contents read from r when the file is sent, without buffering them.
+// The reader can only be consumed once, so requests with such a file can't be retried.
+func (file *File) InitFromReader(r io.Reader, filename string) {
+	file.reader = r
+	file.filename = filename
+	file.multipart = nil
+	file.data = nil
 }
 
 func (file File) MarshalJSON() ([]byte, error) {
@@ -56,6 +68,9 @@ func (file *File) Bytes() ([]byte, error) {
 		defer func() { _ = f.Close() }()
 		return io.ReadAll(f)
 	}
+	if file.reader != nil {
+		return io.ReadAll(file.reader)
+	}
 	return file.data, nil
 }
 
@@ -63,9 +78,21 @@ func (file File) Reader() (io.ReadCloser, error) {
 	if file.multipart != nil {
 		return file.multipart.Open()
 	}
+	if file.reader != nil {
+		if rc, ok := file.reader.(io.ReadCloser); ok {
+			return rc, nil
+		}
+		return io.NopCloser(file.reader), nil
+	}
 	return io.NopCloser(bytes.NewReader(file.data)), nil
 }
 
+// Rewindable returns true if Reader returns the whole contents on every call,
+// which is not the case for files initialized from a reader.
+func (file File) Rewindable() bool {
+	return file.reader == nil
+}
+
 func (file File) Filename() string {
 	if file.multipart != nil {
 		return file.multipart.Filename
@@ -73,9 +100,13 @@ func (file File) Filename() string {
 	return file.filename
 }
 
+// FileSize returns the size of the file, or -1 if it was initialized from a reader.
 func (file File) FileSize() int64 {
 	if file.multipart != nil {
 		return file.multipart.Size
 	}
+	if file.reader != nil {
+		return -1
+	}
 	return int64(len(file.data))
 }
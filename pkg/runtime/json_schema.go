@@ -0,0 +1,529 @@
+// Copyright 2025 DoorDash, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package runtime
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net"
+	"net/url"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// maxSchemaDepth guards against schemas referencing themselves without consuming any data.
+const maxSchemaDepth = 64
+
+// schemaValidator validates decoded JSON values against the schemas of an OpenAPI document.
+// It supports the JSON Schema keywords of OpenAPI 3.0 and the commonly used ones of OpenAPI 3.1.
+// Only local references (#/...) are resolved, schemas behind other references are not validated.
+type schemaValidator struct {
+	doc      map[string]any
+	patterns sync.Map
+}
+
+// validate returns the validation errors of value against schema, field names are prefixed with field.
+// Values are validated as request data, so readOnly properties are not required.
+func (v *schemaValidator) validate(value any, schema any, field string) ValidationErrors {
+	return v.validateDepth(value, schema, field, 0)
+}
+
+func (v *schemaValidator) validateDepth(value any, schema any, field string, depth int) ValidationErrors {
+	if depth > maxSchemaDepth {
+		return nil
+	}
+
+	s, ok := schema.(map[string]any)
+	if !ok {
+		// OpenAPI 3.1 boolean schemas
+		if b, isBool := schema.(bool); isBool && !b {
+			return NewValidationErrorsFromString(field, "is not allowed")
+		}
+		return nil
+	}
+
+	var errs ValidationErrors
+
+	if ref, ok := s["$ref"].(string); ok {
+		if resolved, found := v.resolve(ref); found {
+			errs = append(errs, v.validateDepth(value, resolved, field, depth+1)...)
+		}
+	}
+
+	if value == nil && schemaBool(s, "nullable") {
+		return errs
+	}
+
+	if types := schemaTypes(s); len(types) > 0 && !matchesType(value, types) {
+		return append(errs, ValidationError{Field: field, Message: "must be " + strings.Join(types, " or ")})
+	}
+
+	if enum, ok := s["enum"].([]any); ok && !slices.ContainsFunc(enum, func(e any) bool { return jsonEqual(value, e) }) {
+		values := make([]string, len(enum))
+		for i, e := range enum {
+			values[i] = formatJSONValue(e)
+		}
+		errs = errs.Add(field, "must be one of "+strings.Join(values, ", "))
+	}
+	if c, ok := s["const"]; ok && !jsonEqual(value, c) {
+		errs = errs.Add(field, "must be "+formatJSONValue(c))
+	}
+
+	switch val := value.(type) {
+	case string:
+		errs = append(errs, v.validateString(val, s, field)...)
+	case json.Number, float64, int, int64:
+		if n, ok := toFloat(val); ok {
+			errs = append(errs, validateNumber(n, s, field)...)
+		}
+	case []any:
+		errs = append(errs, v.validateArray(val, s, field, depth)...)
+	case map[string]any:
+		errs = append(errs, v.validateObject(val, s, field, depth)...)
+	}
+
+	errs = append(errs, v.validateComposition(value, s, field, depth)...)
+
+	return errs
+}
+
+func (v *schemaValidator) validateString(val string, s map[string]any, field string) ValidationErrors {
+	var errs ValidationErrors
+	length := utf8.RuneCountInString(val)
+	if minLength, ok := schemaNumber(s, "minLength"); ok && float64(length) < minLength {
+		errs = errs.Add(field, fmt.Sprintf("length must be greater than or equal to %s", formatNumber(minLength)))
+	}
+	if maxLength, ok := schemaNumber(s, "maxLength"); ok && float64(length) > maxLength {
+		errs = errs.Add(field, fmt.Sprintf("length must be less than or equal to %s", formatNumber(maxLength)))
+	}
+	if pattern, ok := s["pattern"].(string); ok {
+		if re := v.pattern(pattern); re != nil && !re.MatchString(val) {
+			errs = errs.Add(field, fmt.Sprintf("must match pattern %q", pattern))
+		}
+	}
+	if format, ok := s["format"].(string); ok && !matchesFormat(val, format) {
+		errs = errs.Add(field, "must be a valid "+format)
+	}
+	return errs
+}
+
+func validateNumber(n float64, s map[string]any, field string) ValidationErrors {
+	var errs ValidationErrors
+
+	if minimum, ok := schemaNumber(s, "minimum"); ok {
+		if schemaBool(s, "exclusiveMinimum") && n <= minimum {
+			errs = errs.Add(field, "must be greater than "+formatNumber(minimum))
+		} else if n < minimum {
+			errs = errs.Add(field, "must be greater than or equal to "+formatNumber(minimum))
+		}
+	}
+	if maximum, ok := schemaNumber(s, "maximum"); ok {
+		if schemaBool(s, "exclusiveMaximum") && n >= maximum {
+			errs = errs.Add(field, "must be less than "+formatNumber(maximum))
+		} else if n > maximum {
+			errs = errs.Add(field, "must be less than or equal to "+formatNumber(maximum))
+		}
+	}
+
+	// OpenAPI 3.1 numeric exclusive bounds
+	if minimum, ok := schemaNumber(s, "exclusiveMinimum"); ok && n <= minimum {
+		errs = errs.Add(field, "must be greater than "+formatNumber(minimum))
+	}
+	if maximum, ok := schemaNumber(s, "exclusiveMaximum"); ok && n >= maximum {
+		errs = errs.Add(field, "must be less than "+formatNumber(maximum))
+	}
+
+	if multipleOf, ok := schemaNumber(s, "multipleOf"); ok && multipleOf > 0 {
+		if q := n / multipleOf; math.Abs(q-math.Round(q)) > 1e-9 {
+			errs = errs.Add(field, "must be a multiple of "+formatNumber(multipleOf))
+		}
+	}
+
+	return errs
+}
+
+func (v *schemaValidator) validateArray(val []any, s map[string]any, field string, depth int) ValidationErrors {
+	var errs ValidationErrors
+	if minItems, ok := schemaNumber(s, "minItems"); ok && float64(len(val)) < minItems {
+		errs = errs.Add(field, fmt.Sprintf("must contain at least %s items", formatNumber(minItems)))
+	}
+	if maxItems, ok := schemaNumber(s, "maxItems"); ok && float64(len(val)) > maxItems {
+		errs = errs.Add(field, fmt.Sprintf("must contain at most %s items", formatNumber(maxItems)))
+	}
+	if schemaBool(s, "uniqueItems") {
+		for i := 1; i < len(val); i++ {
+			if slices.ContainsFunc(val[:i], func(e any) bool { return jsonEqual(e, val[i]) }) {
+				errs = errs.Add(field, "must contain unique items")
+				break
+			}
+		}
+	}
+	if items, ok := s["items"]; ok {
+		for i, item := range val {
+			errs = append(errs, v.validateDepth(item, items, fmt.Sprintf("%s[%d]", field, i), depth+1)...)
+		}
+	}
+	return errs
+}
+
+func (v *schemaValidator) validateObject(val map[string]any, s map[string]any, field string, depth int) ValidationErrors {
+	var errs ValidationErrors
+	properties, _ := s["properties"].(map[string]any)
+
+	if required, ok := s["required"].([]any); ok {
+		for _, r := range required {
+			name, _ := r.(string)
+			if _, found := val[name]; found || name == "" {
+				continue
+			}
+			// readOnly properties are only sent in responses
+			if prop, ok := v.deref(properties[name]).(map[string]any); ok && schemaBool(prop, "readOnly") {
+				continue
+			}
+			errs = errs.Add(joinField(field, name), "is required")
+		}
+	}
+
+	if minProperties, ok := schemaNumber(s, "minProperties"); ok && float64(len(val)) < minProperties {
+		errs = errs.Add(field, fmt.Sprintf("must contain at least %s properties", formatNumber(minProperties)))
+	}
+	if maxProperties, ok := schemaNumber(s, "maxProperties"); ok && float64(len(val)) > maxProperties {
+		errs = errs.Add(field, fmt.Sprintf("must contain at most %s properties", formatNumber(maxProperties)))
+	}
+
+	keys := make([]string, 0, len(val))
+	for key := range val {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	additional, hasAdditional := s["additionalProperties"]
+	for _, key := range keys {
+		if prop, ok := properties[key]; ok {
+			errs = append(errs, v.validateDepth(val[key], prop, joinField(field, key), depth+1)...)
+			continue
+		}
+		if hasAdditional {
+			errs = append(errs, v.validateDepth(val[key], additional, joinField(field, key), depth+1)...)
+		}
+	}
+
+	return errs
+}
+
+func (v *schemaValidator) validateComposition(value any, s map[string]any, field string, depth int) ValidationErrors {
+	var errs ValidationErrors
+
+	if allOf, ok := s["allOf"].([]any); ok {
+		for _, sub := range allOf {
+			errs = append(errs, v.validateDepth(value, sub, field, depth+1)...)
+		}
+	}
+
+	if anyOf, ok := s["anyOf"].([]any); ok {
+		matched := 0
+		for _, sub := range anyOf {
+			if len(v.validateDepth(value, sub, field, depth+1)) == 0 {
+				matched++
+				break
+			}
+		}
+		if matched == 0 {
+			errs = errs.Add(field, "must match at least one schema")
+		}
+	}
+
+	if oneOf, ok := s["oneOf"].([]any); ok {
+		matched := 0
+		for _, sub := range oneOf {
+			if len(v.validateDepth(value, sub, field, depth+1)) == 0 {
+				matched++
+			}
+		}
+		switch {
+		case matched == 0:
+			errs = errs.Add(field, "must match exactly one schema")
+		case matched > 1:
+			errs = errs.Add(field, fmt.Sprintf("must match exactly one schema, but matches %d", matched))
+		}
+	}
+
+	if not, ok := s["not"]; ok && len(v.validateDepth(value, not, field, depth+1)) == 0 {
+		errs = errs.Add(field, "must not match schema")
+	}
+
+	return errs
+}
+
+// resolve returns the value a local reference points to.
+func (v *schemaValidator) resolve(ref string) (any, bool) {
+	pointer, ok := strings.CutPrefix(ref, "#")
+	if !ok {
+		return nil, false
+	}
+
+	var current any = v.doc
+	for _, token := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		if token == "" {
+			continue
+		}
+		if unescaped, err := url.PathUnescape(token); err == nil {
+			token = unescaped
+		}
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+
+		switch c := current.(type) {
+		case map[string]any:
+			if current, ok = c[token]; !ok {
+				return nil, false
+			}
+		case []any:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(c) {
+				return nil, false
+			}
+			current = c[i]
+		default:
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// deref follows the reference of a schema, parameter or request body, if any.
+func (v *schemaValidator) deref(value any) any {
+	for range maxSchemaDepth {
+		m, ok := value.(map[string]any)
+		if !ok {
+			return value
+		}
+		ref, ok := m["$ref"].(string)
+		if !ok {
+			return value
+		}
+		resolved, found := v.resolve(ref)
+		if !found {
+			return value
+		}
+		value = resolved
+	}
+	return value
+}
+
+// compilePatterns compiles the patterns of the schemas found in value, located at path,
+// returning an error for the first one Go can't compile.
+// Examples, default values and extensions are skipped, as they aren't schemas.
+func (v *schemaValidator) compilePatterns(value any, path string) error {
+	switch t := value.(type) {
+	case map[string]any:
+		if pattern, ok := t["pattern"].(string); ok {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return fmt.Errorf("invalid pattern %q at %s: %w", pattern, path, err)
+			}
+			v.patterns.Store(pattern, re)
+		}
+		for key, child := range t {
+			switch {
+			case key == "example" || key == "examples" || key == "default" || key == "enum" || key == "const",
+				strings.HasPrefix(key, "x-"):
+				continue
+			}
+			if err := v.compilePatterns(child, path+"/"+key); err != nil {
+				return err
+			}
+		}
+	case []any:
+		for i, child := range t {
+			if err := v.compilePatterns(child, fmt.Sprintf("%s/%d", path, i)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (v *schemaValidator) pattern(pattern string) *regexp.Regexp {
+	if re, ok := v.patterns.Load(pattern); ok {
+		return re.(*regexp.Regexp)
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		// Patterns of the document are compiled upfront, so this is only reached for patterns of other values
+		return nil
+	}
+	v.patterns.Store(pattern, re)
+	return re
+}
+
+// schemaTypes returns the types of a schema, declared either as a string or as a list (OpenAPI 3.1).
+func schemaTypes(s map[string]any) []string {
+	switch t := s["type"].(type) {
+	case string:
+		return []string{t}
+	case []any:
+		var res []string
+		for _, e := range t {
+			if str, ok := e.(string); ok {
+				res = append(res, str)
+			}
+		}
+		return res
+	}
+	return nil
+}
+
+func matchesType(value any, types []string) bool {
+	for _, t := range types {
+		switch t {
+		case "null":
+			if value == nil {
+				return true
+			}
+		case "boolean":
+			if _, ok := value.(bool); ok {
+				return true
+			}
+		case "string":
+			if _, ok := value.(string); ok {
+				return true
+			}
+		case "number":
+			if _, ok := toFloat(value); ok {
+				return true
+			}
+		case "integer":
+			if n, ok := toFloat(value); ok && n == math.Trunc(n) {
+				return true
+			}
+		case "array":
+			if _, ok := value.([]any); ok {
+				return true
+			}
+		case "object":
+			if _, ok := value.(map[string]any); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func matchesFormat(val, format string) bool {
+	var err error
+	switch format {
+	case "date":
+		_, err = time.Parse(time.DateOnly, val)
+	case "date-time":
+		_, err = time.Parse(time.RFC3339, val)
+	case "email":
+		return emailRegex.MatchString(val)
+	case "uuid":
+		_, err = uuid.Parse(val)
+	case "ipv4":
+		ip := net.ParseIP(val)
+		return ip != nil && ip.To4() != nil && !strings.Contains(val, ":")
+	case "ipv6":
+		ip := net.ParseIP(val)
+		return ip != nil && strings.Contains(val, ":")
+	case "uri":
+		var u *url.URL
+		u, err = url.Parse(val)
+		return err == nil && u.Scheme != ""
+	case "byte":
+		_, err = base64.StdEncoding.DecodeString(val)
+	}
+	return err == nil
+}
+
+func schemaNumber(s map[string]any, key string) (float64, bool) {
+	return toFloat(s[key])
+}
+
+func schemaBool(s map[string]any, key string) bool {
+	b, _ := s[key].(bool)
+	return b
+}
+
+func toFloat(value any) (float64, bool) {
+	switch n := value.(type) {
+	case float64:
+		return n, true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// jsonEqual compares decoded JSON values, regardless of how numbers were decoded.
+func jsonEqual(a, b any) bool {
+	if an, ok := toFloat(a); ok {
+		bn, ok := toFloat(b)
+		return ok && an == bn
+	}
+	switch av := a.(type) {
+	case []any:
+		bv, ok := b.([]any)
+		return ok && slices.EqualFunc(av, bv, jsonEqual)
+	case map[string]any:
+		bv, ok := b.(map[string]any)
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for k, e := range av {
+			if other, found := bv[k]; !found || !jsonEqual(e, other) {
+				return false
+			}
+		}
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func formatJSONValue(value any) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	res, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(res)
+}
+
+func formatNumber(n float64) string {
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
+
+func joinField(field, name string) string {
+	if field == "" {
+		return name
+	}
+	return field + "." + name
+}
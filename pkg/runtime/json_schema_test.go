@@ -0,0 +1,103 @@
+// Copyright 2025 DoorDash, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package runtime
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaValidator(t *testing.T) {
+	doc := map[string]any{}
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"components": {
+			"schemas": {
+				"Node": {
+					"type": "object",
+					"properties": {
+						"value": {"type": "integer"},
+						"next": {"$ref": "#/components/schemas/Node"}
+					}
+				},
+				"a/b": {"type": "string"}
+			}
+		}
+	}`), &doc))
+	v := &schemaValidator{doc: doc}
+
+	tests := []struct {
+		name   string
+		schema string
+		value  string
+		errs   []string
+	}{
+		{name: "type", schema: `{"type": "string"}`, value: `1`, errs: []string{"must be string"}},
+		{name: "integer", schema: `{"type": "integer"}`, value: `2.0`},
+		{name: "not integer", schema: `{"type": "integer"}`, value: `2.5`, errs: []string{"must be integer"}},
+		{name: "type list", schema: `{"type": ["string", "null"]}`, value: `null`},
+		{name: "nullable", schema: `{"type": "string", "nullable": true}`, value: `null`},
+		{name: "not nullable", schema: `{"type": "string"}`, value: `null`, errs: []string{"must be string"}},
+		{name: "enum", schema: `{"enum": ["a", "b"]}`, value: `"c"`, errs: []string{"must be one of a, b"}},
+		{name: "numeric enum", schema: `{"enum": [1, 2]}`, value: `2`},
+		{name: "const", schema: `{"const": {"a": 1}}`, value: `{"a": 2}`, errs: []string{`must be {"a":1}`}},
+		{name: "min length", schema: `{"minLength": 3}`, value: `"ab"`, errs: []string{"length must be greater than or equal to 3"}},
+		{name: "max length counts runes", schema: `{"maxLength": 2}`, value: `"éé"`},
+		{name: "pattern", schema: `{"pattern": "^\\d+$"}`, value: `"12a"`, errs: []string{`must match pattern "^\\d+$"`}},
+		{name: "invalid pattern", schema: `{"pattern": "(?<!a)b"}`, value: `"b"`},
+		{name: "date", schema: `{"format": "date"}`, value: `"2025-02-30"`, errs: []string{"must be a valid date"}},
+		{name: "date-time", schema: `{"format": "date-time"}`, value: `"2025-01-02T03:04:05Z"`},
+		{name: "email", schema: `{"format": "email"}`, value: `"nope"`, errs: []string{"must be a valid email"}},
+		{name: "ipv4", schema: `{"format": "ipv4"}`, value: `"::1"`, errs: []string{"must be a valid ipv4"}},
+		{name: "unknown format", schema: `{"format": "color"}`, value: `"red"`},
+		{name: "minimum", schema: `{"minimum": 1}`, value: `0`, errs: []string{"must be greater than or equal to 1"}},
+		{name: "exclusive minimum 3.0", schema: `{"minimum": 1, "exclusiveMinimum": true}`, value: `1`, errs: []string{"must be greater than 1"}},
+		{name: "exclusive maximum 3.1", schema: `{"exclusiveMaximum": 10}`, value: `10`, errs: []string{"must be less than 10"}},
+		{name: "multiple of", schema: `{"multipleOf": 0.1}`, value: `0.3`},
+		{name: "not multiple of", schema: `{"multipleOf": 5}`, value: `12`, errs: []string{"must be a multiple of 5"}},
+		{name: "items", schema: `{"items": {"type": "integer"}}`, value: `[1, "a"]`, errs: []string{"[1] must be integer"}},
+		{name: "min items", schema: `{"minItems": 2}`, value: `[1]`, errs: []string{"must contain at least 2 items"}},
+		{name: "unique items", schema: `{"uniqueItems": true}`, value: `[{"a": 1}, {"a": 1.0}]`, errs: []string{"must contain unique items"}},
+		{name: "required", schema: `{"required": ["a"]}`, value: `{}`, errs: []string{"a is required"}},
+		{name: "additional properties", schema: `{"properties": {"a": {}}, "additionalProperties": {"type": "integer"}}`,
+			value: `{"a": "x", "b": "y"}`, errs: []string{"b must be integer"}},
+		{name: "max properties", schema: `{"maxProperties": 1}`, value: `{"a": 1, "b": 2}`, errs: []string{"must contain at most 1 properties"}},
+		{name: "all of", schema: `{"allOf": [{"required": ["a"]}, {"required": ["b"]}]}`, value: `{"a": 1}`, errs: []string{"b is required"}},
+		{name: "any of", schema: `{"anyOf": [{"type": "string"}, {"type": "integer"}]}`, value: `true`, errs: []string{"must match at least one schema"}},
+		{name: "one of", schema: `{"oneOf": [{"type": "number"}, {"type": "integer"}]}`, value: `1`, errs: []string{"must match exactly one schema, but matches 2"}},
+		{name: "not", schema: `{"not": {"type": "string"}}`, value: `"a"`, errs: []string{"must not match schema"}},
+		{name: "false schema", schema: `{"properties": {"a": false}}`, value: `{"a": 1}`, errs: []string{"a is not allowed"}},
+		{name: "recursive ref", schema: `{"$ref": "#/components/schemas/Node"}`, value: `{"value": 1, "next": {"value": "x"}}`,
+			errs: []string{"next.value must be integer"}},
+		{name: "escaped ref", schema: `{"$ref": "#/components/schemas/a~1b"}`, value: `1`, errs: []string{"must be string"}},
+		{name: "unresolved ref", schema: `{"$ref": "other.yml#/Pet"}`, value: `1`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var schema any
+			require.NoError(t, json.Unmarshal([]byte(tc.schema), &schema))
+			dec := json.NewDecoder(strings.NewReader(tc.value))
+			dec.UseNumber()
+			var value any
+			require.NoError(t, dec.Decode(&value))
+
+			var errs []string
+			for _, e := range v.validate(value, schema, "") {
+				errs = append(errs, strings.TrimSpace(e.Error()))
+			}
+			assert.Equal(t, tc.errs, errs)
+		})
+	}
+}
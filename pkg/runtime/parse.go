@@ -11,6 +11,7 @@
 package runtime
 
 import (
+	"fmt"
 	"strconv"
 	"time"
 
@@ -135,3 +136,31 @@ func ParseStringSlice[T any](values []string, format ...string) ([]T, error) {
 	}
 	return result, nil
 }
+
+// FormatString formats v as a string ParseString parses back, e.g. to send it as a header value.
+// Times are formatted as RFC 3339, types implementing fmt.Stringer with their String method
+// and other types, including string-based enums, with fmt.
+func FormatString[T any](v T) string {
+	switch x := any(v).(type) {
+	case string:
+		return x
+	case time.Time:
+		return x.Format(time.RFC3339)
+	case float32:
+		return strconv.FormatFloat(float64(x), 'f', -1, 32)
+	case float64:
+		return strconv.FormatFloat(x, 'f', -1, 64)
+	case fmt.Stringer:
+		return x.String()
+	}
+	return fmt.Sprint(v)
+}
+
+// FormatStringSlice formats each value with FormatString.
+func FormatStringSlice[T any](values []T) []string {
+	result := make([]string, len(values))
+	for i, v := range values {
+		result[i] = FormatString(v)
+	}
+	return result
+}
@@ -222,3 +222,30 @@ func TestParseStringSlice(t *testing.T) {
 		assert.Nil(t, result)
 	})
 }
+
+func TestFormatString(t *testing.T) {
+	type color string
+
+	assert.Equal(t, "42", FormatString(42))
+	assert.Equal(t, "-7", FormatString(int64(-7)))
+	assert.Equal(t, "1.5", FormatString(1.5))
+	assert.Equal(t, "1000000", FormatString(float32(1e6)))
+	assert.Equal(t, "true", FormatString(true))
+	assert.Equal(t, "hello", FormatString("hello"))
+	assert.Equal(t, "red", FormatString(color("red")))
+	assert.Equal(t, "550e8400-e29b-41d4-a716-446655440000", FormatString(uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")))
+	assert.Equal(t, "2025-01-02T03:04:05Z", FormatString(time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)))
+	assert.Equal(t, "2025-01-02", FormatString(Date{Time: time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)}))
+
+	t.Run("round trips with ParseString", func(t *testing.T) {
+		ts := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+		parsed, err := ParseString[time.Time](FormatString(ts), "date-time")
+		require.NoError(t, err)
+		assert.True(t, ts.Equal(parsed))
+	})
+}
+
+func TestFormatStringSlice(t *testing.T) {
+	assert.Equal(t, []string{"1", "2", "3"}, FormatStringSlice([]int{1, 2, 3}))
+	assert.Empty(t, FormatStringSlice[string](nil))
+}
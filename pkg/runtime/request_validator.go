@@ -0,0 +1,616 @@
+// Copyright 2025 DoorDash, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package runtime
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.yaml.in/yaml/v4"
+)
+
+var specPathParamPattern = regexp.MustCompile(`\{([^}/]+)}`)
+
+// DefaultRequestValidatorMaxBodySize is the size of the largest request body a RequestValidator reads, 10 MiB.
+const DefaultRequestValidatorMaxBodySize = 10 << 20
+
+// RequestValidator validates HTTP requests against the operations of an OpenAPI spec.
+// It checks path, query, header and cookie parameters, the content type and the body schema.
+// As it works on the spec alone, it enforces keywords the generated types can't express,
+// and can validate requests to services generated from another revision of the spec.
+type RequestValidator struct {
+	schemas     *schemaValidator
+	routes      []specRoute
+	basePath    string
+	maxBodySize int64
+}
+
+// specRoute is an operation of the spec along with the pattern matching its path.
+type specRoute struct {
+	method      string
+	pattern     *regexp.Regexp
+	paramNames  []string
+	operationID string
+	parameters  []map[string]any
+	requestBody map[string]any
+}
+
+// RequestValidatorOption configures a RequestValidator.
+type RequestValidatorOption func(*RequestValidator)
+
+// WithRequestValidatorBasePath sets the prefix stripped from request paths before matching them to the spec paths,
+// for APIs mounted under the path of a server URL, e.g. "/v1".
+func WithRequestValidatorBasePath(basePath string) RequestValidatorOption {
+	return func(v *RequestValidator) {
+		v.basePath = strings.TrimSuffix(basePath, "/")
+	}
+}
+
+// WithRequestValidatorMaxBodySize sets the size of the largest request body read for validation,
+// DefaultRequestValidatorMaxBodySize by default. Larger bodies fail with an *http.MaxBytesError.
+func WithRequestValidatorMaxBodySize(size int64) RequestValidatorOption {
+	return func(v *RequestValidator) {
+		v.maxBodySize = size
+	}
+}
+
+// NewRequestValidator creates a RequestValidator from an OpenAPI 3 spec in JSON or YAML.
+// It fails if the spec has patterns Go regular expressions can't compile, as they couldn't be enforced.
+func NewRequestValidator(spec []byte, opts ...RequestValidatorOption) (*RequestValidator, error) {
+	var raw any
+	if err := json.Unmarshal(spec, &raw); err != nil {
+		if err = yaml.Unmarshal(spec, &raw); err != nil {
+			return nil, fmt.Errorf("error parsing spec: %w", err)
+		}
+	}
+	doc, ok := normalizeSpecValue(raw).(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("error parsing spec: not an object")
+	}
+
+	v := &RequestValidator{schemas: &schemaValidator{doc: doc}, maxBodySize: DefaultRequestValidatorMaxBodySize}
+	for _, opt := range opts {
+		opt(v)
+	}
+	if err := v.schemas.compilePatterns(doc, "#"); err != nil {
+		return nil, err
+	}
+
+	paths, _ := doc["paths"].(map[string]any)
+	for path, item := range paths {
+		pathItem, ok := v.schemas.deref(item).(map[string]any)
+		if !ok {
+			continue
+		}
+
+		pattern, paramNames := specPathPattern(path)
+		pathParams, _ := pathItem["parameters"].([]any)
+		for _, method := range []string{
+			http.MethodGet, http.MethodPut, http.MethodPost, http.MethodDelete,
+			http.MethodOptions, http.MethodHead, http.MethodPatch, http.MethodTrace,
+		} {
+			op, ok := pathItem[strings.ToLower(method)].(map[string]any)
+			if !ok {
+				continue
+			}
+			opParams, _ := op["parameters"].([]any)
+			operationID, _ := op["operationId"].(string)
+			requestBody, _ := v.schemas.deref(op["requestBody"]).(map[string]any)
+
+			v.routes = append(v.routes, specRoute{
+				method:      method,
+				pattern:     pattern,
+				paramNames:  paramNames,
+				operationID: operationID,
+				parameters:  v.mergeParameters(pathParams, opParams),
+				requestBody: requestBody,
+			})
+		}
+	}
+
+	// Concrete paths take precedence over templated ones
+	sort.SliceStable(v.routes, func(i, j int) bool {
+		if len(v.routes[i].paramNames) != len(v.routes[j].paramNames) {
+			return len(v.routes[i].paramNames) < len(v.routes[j].paramNames)
+		}
+		return v.routes[i].pattern.String() < v.routes[j].pattern.String()
+	})
+
+	return v, nil
+}
+
+// ValidateRequest validates the request against the operation matching its method and path.
+// It returns the operation ID, and ValidationErrors if the request is invalid.
+// Requests not matching any operation are not validated.
+// The request body is read and replaced, so it can still be consumed by the handler.
+func (v *RequestValidator) ValidateRequest(r *http.Request) (string, error) {
+	route, pathValues := v.match(r)
+	if route == nil {
+		return "", nil
+	}
+
+	var errs ValidationErrors
+	for _, param := range route.parameters {
+		errs = append(errs, v.validateParameter(r, param, pathValues)...)
+	}
+
+	bodyErrs, err := v.validateBody(r, route.requestBody)
+	if err != nil {
+		return route.operationID, err
+	}
+	errs = append(errs, bodyErrs...)
+
+	if len(errs) > 0 {
+		return route.operationID, errs
+	}
+	return route.operationID, nil
+}
+
+// Middleware returns a net/http middleware validating requests before passing them on.
+// Invalid requests are reported to onError instead, with the operation ID and the validation errors.
+// If onError is nil, invalid requests are rejected with 400 Bad Request and the errors as plain text,
+// or 413 Request Entity Too Large if the body is larger than the maximum size.
+func (v *RequestValidator) Middleware(onError func(w http.ResponseWriter, r *http.Request, operationID string, err error)) func(http.Handler) http.Handler {
+	if onError == nil {
+		onError = func(w http.ResponseWriter, _ *http.Request, _ string, err error) {
+			status := http.StatusBadRequest
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				status = http.StatusRequestEntityTooLarge
+			}
+			http.Error(w, err.Error(), status)
+		}
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if operationID, err := v.ValidateRequest(r); err != nil {
+				onError(w, r, operationID, err)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// match returns the route of the request along with the raw values of the path parameters.
+func (v *RequestValidator) match(r *http.Request) (*specRoute, map[string]string) {
+	path := r.URL.EscapedPath()
+	if v.basePath != "" {
+		trimmed, ok := strings.CutPrefix(path, v.basePath)
+		if !ok {
+			return nil, nil
+		}
+		path = trimmed
+	}
+
+	for i := range v.routes {
+		route := &v.routes[i]
+		if route.method != r.Method {
+			continue
+		}
+		matches := route.pattern.FindStringSubmatch(path)
+		if matches == nil {
+			continue
+		}
+		values := make(map[string]string, len(route.paramNames))
+		for j, name := range route.paramNames {
+			value, err := url.PathUnescape(matches[j+1])
+			if err != nil {
+				value = matches[j+1]
+			}
+			values[name] = value
+		}
+		return route, values
+	}
+	return nil, nil
+}
+
+// mergeParameters combines the path item and operation parameters, the latter overriding the former.
+func (v *RequestValidator) mergeParameters(pathParams, opParams []any) []map[string]any {
+	var res []map[string]any
+	index := map[string]int{}
+	for _, p := range slices.Concat(pathParams, opParams) {
+		param, ok := v.schemas.deref(p).(map[string]any)
+		if !ok {
+			continue
+		}
+		name, _ := param["name"].(string)
+		in, _ := param["in"].(string)
+		key := in + ":" + name
+		if i, found := index[key]; found {
+			res[i] = param
+			continue
+		}
+		index[key] = len(res)
+		res = append(res, param)
+	}
+	return res
+}
+
+func (v *RequestValidator) validateParameter(r *http.Request, param map[string]any, pathValues map[string]string) ValidationErrors {
+	name, _ := param["name"].(string)
+	in, _ := param["in"].(string)
+	field := in + "." + name
+	required := schemaBool(param, "required") || in == "path"
+
+	var values []string
+	switch in {
+	case "path":
+		if value, ok := pathValues[name]; ok {
+			values = []string{value}
+		}
+	case "query":
+		values = r.URL.Query()[name]
+	case "header":
+		values = r.Header.Values(name)
+	case "cookie":
+		if cookie, err := r.Cookie(name); err == nil {
+			values = []string{cookie.Value}
+		}
+	default:
+		return nil
+	}
+
+	// Parameters with content are serialized as a whole, e.g. JSON encoded
+	if content, ok := param["content"].(map[string]any); ok {
+		if len(values) == 0 {
+			if required {
+				return NewValidationErrorsFromString(field, "is required")
+			}
+			return nil
+		}
+		for _, media := range content {
+			m, _ := media.(map[string]any)
+			var value any
+			if err := json.Unmarshal([]byte(values[0]), &value); err != nil {
+				return NewValidationErrorsFromString(field, "must be valid JSON")
+			}
+			return v.schemas.validate(value, m["schema"], field)
+		}
+		return nil
+	}
+
+	schema, _ := v.schemas.deref(param["schema"]).(map[string]any)
+	style, _ := param["style"].(string)
+	if style == "" {
+		style = "simple"
+		if in == "query" || in == "cookie" {
+			style = "form"
+		}
+	}
+	explode := style == "form"
+	if e, ok := param["explode"].(bool); ok {
+		explode = e
+	}
+
+	var (
+		value any
+		err   error
+	)
+	switch {
+	case in == "query" && schemaHasType(schema, "object") && (style == "deepObject" || explode):
+		value, err = v.queryObjectValue(r.URL.Query(), name, style, schema)
+		if value == nil && err == nil {
+			values = nil
+		}
+	case len(values) == 0:
+	case schemaHasType(schema, "array"):
+		value, err = v.arrayValue(values, style, explode, schema)
+	case schemaHasType(schema, "object"):
+		value, err = v.objectValue(values[0], schema)
+	default:
+		value, err = v.scalarValue(values[0], schema)
+	}
+
+	if err != nil {
+		return NewValidationErrorsFromString(field, err.Error())
+	}
+	if len(values) == 0 && value == nil {
+		if required {
+			return NewValidationErrorsFromString(field, "is required")
+		}
+		return nil
+	}
+	return v.schemas.validate(value, param["schema"], field)
+}
+
+// arrayValue splits the raw values of an array parameter according to its style.
+func (v *RequestValidator) arrayValue(values []string, style string, explode bool, schema map[string]any) (any, error) {
+	if !explode || len(values) == 1 {
+		delimiter := ","
+		switch style {
+		case "spaceDelimited":
+			delimiter = " "
+		case "pipeDelimited":
+			delimiter = "|"
+		}
+		var split []string
+		for _, value := range values {
+			if value == "" {
+				continue
+			}
+			split = append(split, strings.Split(value, delimiter)...)
+		}
+		values = split
+	}
+
+	items, _ := v.schemas.deref(schema["items"]).(map[string]any)
+	res := make([]any, 0, len(values))
+	for i, value := range values {
+		item, err := v.scalarValue(value, items)
+		if err != nil {
+			return nil, fmt.Errorf("item %d %w", i, err)
+		}
+		res = append(res, item)
+	}
+	return res, nil
+}
+
+// objectValue decodes an object parameter serialized as comma separated key and value pairs.
+func (v *RequestValidator) objectValue(value string, schema map[string]any) (any, error) {
+	parts := strings.Split(value, ",")
+	if len(parts)%2 != 0 {
+		return nil, fmt.Errorf("must be a list of key and value pairs")
+	}
+	res := make(map[string]any, len(parts)/2)
+	for i := 0; i < len(parts); i += 2 {
+		prop, err := v.scalarValue(parts[i+1], v.propertySchema(schema, parts[i]))
+		if err != nil {
+			return nil, fmt.Errorf("property %s %w", parts[i], err)
+		}
+		res[parts[i]] = prop
+	}
+	return res, nil
+}
+
+// queryObjectValue collects the properties of an exploded object query parameter,
+// either name[key]=value (deepObject) or key=value (form).
+func (v *RequestValidator) queryObjectValue(query url.Values, name, style string, schema map[string]any) (any, error) {
+	res := map[string]any{}
+	for key, values := range query {
+		prop := key
+		if style == "deepObject" {
+			inner, ok := strings.CutPrefix(key, name+"[")
+			if !ok || !strings.HasSuffix(inner, "]") {
+				continue
+			}
+			prop = strings.TrimSuffix(inner, "]")
+		} else if _, declared := v.properties(schema)[prop]; !declared {
+			continue
+		}
+
+		propSchema := v.propertySchema(schema, prop)
+		var (
+			value any
+			err   error
+		)
+		if schemaHasType(propSchema, "array") {
+			value, err = v.arrayValue(values, "form", true, propSchema)
+		} else {
+			value, err = v.scalarValue(values[0], propSchema)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("property %s %w", prop, err)
+		}
+		res[prop] = value
+	}
+	if len(res) == 0 {
+		return nil, nil
+	}
+	return res, nil
+}
+
+// scalarValue converts a raw parameter value to the JSON type of its schema.
+func (v *RequestValidator) scalarValue(value string, schema map[string]any) (any, error) {
+	types := schemaTypes(schema)
+	if len(types) == 0 {
+		return value, nil
+	}
+	for _, t := range types {
+		switch t {
+		case "string":
+			return value, nil
+		case "integer":
+			if _, err := strconv.ParseInt(value, 10, 64); err == nil {
+				return json.Number(value), nil
+			}
+		case "number":
+			if _, err := strconv.ParseFloat(value, 64); err == nil {
+				return json.Number(value), nil
+			}
+		case "boolean":
+			if b, err := strconv.ParseBool(value); err == nil {
+				return b, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("must be %s", strings.Join(types, " or "))
+}
+
+func (v *RequestValidator) validateBody(r *http.Request, requestBody map[string]any) (ValidationErrors, error) {
+	if requestBody == nil {
+		return nil, nil
+	}
+
+	var data []byte
+	if r.Body != nil && r.Body != http.NoBody {
+		var err error
+		data, err = io.ReadAll(http.MaxBytesReader(nil, r.Body, v.maxBodySize))
+		_ = r.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("error reading request body: %w", err)
+		}
+		r.Body = io.NopCloser(bytes.NewReader(data))
+	}
+
+	if len(data) == 0 {
+		if schemaBool(requestBody, "required") {
+			return NewValidationErrorsFromString("body", "is required"), nil
+		}
+		return nil, nil
+	}
+
+	content, _ := requestBody["content"].(map[string]any)
+	if len(content) == 0 {
+		return nil, nil
+	}
+
+	contentType := r.Header.Get("Content-Type")
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return NewValidationErrorsFromString("Content-Type", "is required"), nil
+	}
+
+	media, found := specMediaType(content, mediaType)
+	if !found {
+		types := make([]string, 0, len(content))
+		for t := range content {
+			types = append(types, t)
+		}
+		sort.Strings(types)
+		return NewValidationErrorsFromString("Content-Type",
+			fmt.Sprintf("%s is not supported, must be one of %s", mediaType, strings.Join(types, ", "))), nil
+	}
+
+	schema := media["schema"]
+	switch {
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.UseNumber()
+		var value any
+		if err := dec.Decode(&value); err != nil {
+			return NewValidationErrorsFromString("body", "must be valid JSON"), nil
+		}
+		return v.schemas.validate(value, schema, "body"), nil
+
+	case mediaType == "application/x-www-form-urlencoded":
+		form, err := url.ParseQuery(string(data))
+		if err != nil {
+			return NewValidationErrorsFromString("body", "must be valid form data"), nil
+		}
+		objSchema, _ := v.schemas.deref(schema).(map[string]any)
+		value, err := v.queryObjectValue(form, "", "form", objSchema)
+		if err != nil {
+			return NewValidationErrorsFromString("body", err.Error()), nil
+		}
+		if value == nil {
+			value = map[string]any{}
+		}
+		return v.schemas.validate(value, schema, "body"), nil
+	}
+
+	return nil, nil
+}
+
+// properties returns the properties of an object schema, including those of its allOf elements.
+func (v *RequestValidator) properties(schema map[string]any) map[string]any {
+	res := map[string]any{}
+	if props, ok := schema["properties"].(map[string]any); ok {
+		for k, p := range props {
+			res[k] = p
+		}
+	}
+	if allOf, ok := schema["allOf"].([]any); ok {
+		for _, sub := range allOf {
+			if s, ok := v.schemas.deref(sub).(map[string]any); ok {
+				for k, p := range v.properties(s) {
+					res[k] = p
+				}
+			}
+		}
+	}
+	return res
+}
+
+func (v *RequestValidator) propertySchema(schema map[string]any, name string) map[string]any {
+	if prop, ok := v.schemas.deref(v.properties(schema)[name]).(map[string]any); ok {
+		return prop
+	}
+	res, _ := v.schemas.deref(schema["additionalProperties"]).(map[string]any)
+	return res
+}
+
+// specMediaType returns the declared media type matching the request content type,
+// preferring exact matches over ranges like image/* or */*.
+func specMediaType(content map[string]any, mediaType string) (map[string]any, bool) {
+	major, _, _ := strings.Cut(mediaType, "/")
+	for _, candidate := range []string{mediaType, major + "/*", "*/*"} {
+		for declared, media := range content {
+			if parsed, _, err := mime.ParseMediaType(declared); err == nil && strings.EqualFold(parsed, candidate) {
+				m, _ := media.(map[string]any)
+				return m, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// specPathPattern converts a spec path template into a pattern matching escaped request paths.
+func specPathPattern(path string) (*regexp.Regexp, []string) {
+	var (
+		pattern strings.Builder
+		names   []string
+		last    int
+	)
+	pattern.WriteString("^")
+	for _, loc := range specPathParamPattern.FindAllStringSubmatchIndex(path, -1) {
+		pattern.WriteString(regexp.QuoteMeta(path[last:loc[0]]))
+		pattern.WriteString("([^/]+)")
+		names = append(names, path[loc[2]:loc[3]])
+		last = loc[1]
+	}
+	pattern.WriteString(regexp.QuoteMeta(path[last:]))
+	pattern.WriteString("/?$")
+	return regexp.MustCompile(pattern.String()), names
+}
+
+func schemaHasType(schema map[string]any, t string) bool {
+	for _, st := range schemaTypes(schema) {
+		if st == t {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeSpecValue converts YAML mappings with non-string keys, e.g. response codes, to JSON objects.
+func normalizeSpecValue(value any) any {
+	switch val := value.(type) {
+	case map[string]any:
+		for k, e := range val {
+			val[k] = normalizeSpecValue(e)
+		}
+		return val
+	case map[any]any:
+		res := make(map[string]any, len(val))
+		for k, e := range val {
+			res[fmt.Sprint(k)] = normalizeSpecValue(e)
+		}
+		return res
+	case []any:
+		for i, e := range val {
+			val[i] = normalizeSpecValue(e)
+		}
+		return val
+	}
+	return value
+}
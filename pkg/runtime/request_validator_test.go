@@ -0,0 +1,332 @@
+// Copyright 2025 DoorDash, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package runtime
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const requestValidatorSpec = `
+openapi: 3.0.0
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+            minimum: 1
+            maximum: 100
+        - name: tags
+          in: query
+          schema:
+            type: array
+            items:
+              type: string
+            maxItems: 2
+        - name: filter
+          in: query
+          style: deepObject
+          schema:
+            type: object
+            properties:
+              age:
+                type: integer
+        - name: X-Request-ID
+          in: header
+          required: true
+          schema:
+            type: string
+            format: uuid
+        - name: session
+          in: cookie
+          schema:
+            type: string
+            minLength: 4
+      responses:
+        200:
+          description: OK
+    post:
+      operationId: createPet
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/NewPet'
+          application/x-www-form-urlencoded:
+            schema:
+              $ref: '#/components/schemas/NewPet'
+      responses:
+        201:
+          description: Created
+  /pets/{id}:
+    parameters:
+      - name: id
+        in: path
+        required: true
+        schema:
+          type: integer
+    get:
+      operationId: getPet
+      responses:
+        200:
+          description: OK
+  /pets/mine:
+    get:
+      operationId: listMyPets
+      responses:
+        200:
+          description: OK
+components:
+  schemas:
+    NewPet:
+      type: object
+      required: [id, name]
+      additionalProperties: false
+      properties:
+        id:
+          type: integer
+          readOnly: true
+        name:
+          type: string
+          pattern: '^[A-Z]'
+        age:
+          type: integer
+          multipleOf: 1
+`
+
+func TestRequestValidator(t *testing.T) {
+	v, err := NewRequestValidator([]byte(requestValidatorSpec))
+	require.NoError(t, err)
+
+	validate := func(t *testing.T, r *http.Request) (string, ValidationErrors) {
+		t.Helper()
+		operationID, err := v.ValidateRequest(r)
+		if err == nil {
+			return operationID, nil
+		}
+		var errs ValidationErrors
+		require.ErrorAs(t, err, &errs)
+		return operationID, errs
+	}
+
+	fields := func(errs ValidationErrors) []string {
+		var res []string
+		for _, e := range errs {
+			res = append(res, e.Field+" "+e.Message)
+		}
+		return res
+	}
+
+	t.Run("valid parameters", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/pets?limit=10&tags=a&tags=b&filter[age]=3", nil)
+		r.Header.Set("X-Request-ID", "5f0c6a3e-8f4b-4c39-9d0e-3d1a6a6f0b1c")
+		r.AddCookie(&http.Cookie{Name: "session", Value: "abcd"})
+
+		operationID, errs := validate(t, r)
+		assert.Equal(t, "listPets", operationID)
+		assert.Empty(t, errs)
+	})
+
+	t.Run("invalid parameters", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/pets?limit=0&tags=a,b,c&filter[age]=old", nil)
+		r.AddCookie(&http.Cookie{Name: "session", Value: "ab"})
+
+		_, errs := validate(t, r)
+		assert.Equal(t, []string{
+			"query.limit must be greater than or equal to 1",
+			"query.tags must contain at most 2 items",
+			"query.filter property age must be integer",
+			"header.X-Request-ID is required",
+			"cookie.session length must be greater than or equal to 4",
+		}, fields(errs))
+	})
+
+	t.Run("invalid header format", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/pets", nil)
+		r.Header.Set("X-Request-ID", "not-a-uuid")
+
+		_, errs := validate(t, r)
+		assert.Equal(t, []string{"header.X-Request-ID must be a valid uuid"}, fields(errs))
+	})
+
+	t.Run("path parameters", func(t *testing.T) {
+		operationID, errs := validate(t, httptest.NewRequest(http.MethodGet, "/pets/12", nil))
+		assert.Equal(t, "getPet", operationID)
+		assert.Empty(t, errs)
+
+		_, errs = validate(t, httptest.NewRequest(http.MethodGet, "/pets/abc", nil))
+		assert.Equal(t, []string{"path.id must be integer"}, fields(errs))
+	})
+
+	t.Run("concrete paths take precedence", func(t *testing.T) {
+		operationID, errs := validate(t, httptest.NewRequest(http.MethodGet, "/pets/mine", nil))
+		assert.Equal(t, "listMyPets", operationID)
+		assert.Empty(t, errs)
+	})
+
+	t.Run("unknown routes are not validated", func(t *testing.T) {
+		operationID, errs := validate(t, httptest.NewRequest(http.MethodDelete, "/pets/12", nil))
+		assert.Empty(t, operationID)
+		assert.Empty(t, errs)
+	})
+
+	t.Run("valid json body", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "/pets", strings.NewReader(`{"name":"Rex","age":3}`))
+		r.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+		operationID, errs := validate(t, r)
+		assert.Equal(t, "createPet", operationID)
+		assert.Empty(t, errs)
+
+		// the body can still be read by the handler
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"name":"Rex","age":3}`, string(body))
+	})
+
+	t.Run("invalid json body", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "/pets", strings.NewReader(`{"name":"rex","age":3.5,"color":"brown"}`))
+		r.Header.Set("Content-Type", "application/json")
+
+		_, errs := validate(t, r)
+		assert.Equal(t, []string{
+			"body.age must be integer",
+			"body.color is not allowed",
+			`body.name must match pattern "^[A-Z]"`,
+		}, fields(errs))
+	})
+
+	t.Run("form body", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "/pets", strings.NewReader(`name=Rex&age=x`))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		_, errs := validate(t, r)
+		assert.Equal(t, []string{"body property age must be integer"}, fields(errs))
+	})
+
+	t.Run("missing body", func(t *testing.T) {
+		_, errs := validate(t, httptest.NewRequest(http.MethodPost, "/pets", nil))
+		assert.Equal(t, []string{"body is required"}, fields(errs))
+	})
+
+	t.Run("unsupported content type", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "/pets", strings.NewReader(`<pet/>`))
+		r.Header.Set("Content-Type", "application/xml")
+
+		_, errs := validate(t, r)
+		assert.Equal(t, []string{
+			"Content-Type application/xml is not supported, must be one of application/json, application/x-www-form-urlencoded",
+		}, fields(errs))
+	})
+
+	t.Run("base path", func(t *testing.T) {
+		v, err := NewRequestValidator([]byte(requestValidatorSpec), WithRequestValidatorBasePath("/v1/"))
+		require.NoError(t, err)
+
+		operationID, err := v.ValidateRequest(httptest.NewRequest(http.MethodGet, "/v1/pets/abc", nil))
+		assert.Equal(t, "getPet", operationID)
+		assert.Error(t, err)
+	})
+
+	t.Run("json spec", func(t *testing.T) {
+		v, err := NewRequestValidator([]byte(`{"openapi":"3.1.0","paths":{"/items/{id}":{"get":{"operationId":"getItem",
+			"parameters":[{"name":"id","in":"path","required":true,"schema":{"type":["integer","null"],"exclusiveMinimum":0}}]}}}}`))
+		require.NoError(t, err)
+
+		operationID, err := v.ValidateRequest(httptest.NewRequest(http.MethodGet, "/items/0", nil))
+		assert.Equal(t, "getItem", operationID)
+		assert.EqualError(t, err, "path.id must be greater than 0")
+	})
+
+	t.Run("invalid spec", func(t *testing.T) {
+		_, err := NewRequestValidator([]byte(`- not an object`))
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid pattern", func(t *testing.T) {
+		_, err := NewRequestValidator([]byte(`{"openapi":"3.0.0","components":{"schemas":{"Code":{"type":"string","pattern":"^(?!x)"}}}}`))
+		assert.ErrorContains(t, err, `invalid pattern "^(?!x)" at #/components/schemas/Code`)
+	})
+
+	t.Run("body too large", func(t *testing.T) {
+		v, err := NewRequestValidator([]byte(requestValidatorSpec), WithRequestValidatorMaxBodySize(8))
+		require.NoError(t, err)
+
+		r := httptest.NewRequest(http.MethodPost, "/pets", strings.NewReader(`{"name":"Rex"}`))
+		r.Header.Set("Content-Type", "application/json")
+
+		_, err = v.ValidateRequest(r)
+		var maxBytesErr *http.MaxBytesError
+		require.ErrorAs(t, err, &maxBytesErr)
+		assert.Equal(t, int64(8), maxBytesErr.Limit)
+	})
+}
+
+func TestRequestValidatorMiddleware(t *testing.T) {
+	v, err := NewRequestValidator([]byte(requestValidatorSpec))
+	require.NoError(t, err)
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	t.Run("default error response", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		v.Middleware(nil)(next).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/pets/abc", nil))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, "path.id must be integer\n", rec.Body.String())
+	})
+
+	t.Run("custom error handler", func(t *testing.T) {
+		var gotOperationID string
+		onError := func(w http.ResponseWriter, r *http.Request, operationID string, err error) {
+			gotOperationID = operationID
+			w.WriteHeader(http.StatusUnprocessableEntity)
+		}
+
+		rec := httptest.NewRecorder()
+		v.Middleware(onError)(next).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/pets/abc", nil))
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Equal(t, "getPet", gotOperationID)
+	})
+
+	t.Run("valid request", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		v.Middleware(nil)(next).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/pets/1", nil))
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+	})
+
+	t.Run("body too large", func(t *testing.T) {
+		small, err := NewRequestValidator([]byte(requestValidatorSpec), WithRequestValidatorMaxBodySize(8))
+		require.NoError(t, err)
+
+		r := httptest.NewRequest(http.MethodPost, "/pets", strings.NewReader(`{"name":"Rex"}`))
+		r.Header.Set("Content-Type", "application/json")
+
+		rec := httptest.NewRecorder()
+		small.Middleware(nil)(next).ServeHTTP(rec, r)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+	})
+}
@@ -0,0 +1,265 @@
+// Copyright 2025 DoorDash, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// IdempotencyKeyHeader is the header making a non-idempotent request safe to retry.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// RetryPolicy configures how the Client retries failed requests.
+// Start from DefaultRetryPolicy and adjust it as needed.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first one.
+	MaxAttempts int
+
+	// InitialBackoff is the delay before the first retry.
+	InitialBackoff time.Duration
+
+	// MaxBackoff caps the delay between two attempts, except for delays set with the Retry-After header.
+	MaxBackoff time.Duration
+
+	// MaxRetryAfter is the longest delay set with the Retry-After header the client waits for.
+	// When the response asks for a longer one, retries stop and the response is returned.
+	// MaxBackoff is used when zero, delays are not limited if both are zero.
+	MaxRetryAfter time.Duration
+
+	// Multiplier is the factor the delay grows by on every retry.
+	Multiplier float64
+
+	// Jitter is the fraction of the delay that is randomized, between 0 and 1.
+	// With 0.2, a delay of 1s becomes a random delay between 0.8s and 1s.
+	Jitter float64
+
+	// StatusCodes are the response status codes that are retried.
+	StatusCodes []int
+
+	// RetryError reports whether a request failing with err is retried.
+	// IsRetryableError is used when nil.
+	RetryError func(err error) bool
+
+	// Methods are the HTTP methods that are retried.
+	// Requests with other methods are only retried when they have an Idempotency-Key header.
+	Methods []string
+
+	// IdempotencyKey, when set, generates the Idempotency-Key header of POST and PATCH requests without one,
+	// which makes them retryable. See NewIdempotencyKey.
+	IdempotencyKey func() string
+}
+
+// DefaultRetryPolicy returns a policy making up to 3 attempts of idempotent requests,
+// on transport errors and 429, 502, 503 and 504 responses, with an exponential backoff starting at 100ms.
+// Responses asking to retry after more than 30s are returned as is.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     5 * time.Second,
+		MaxRetryAfter:  30 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+		StatusCodes: []int{
+			http.StatusTooManyRequests,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+		Methods: []string{
+			http.MethodGet,
+			http.MethodHead,
+			http.MethodOptions,
+			http.MethodTrace,
+			http.MethodPut,
+			http.MethodDelete,
+		},
+	}
+}
+
+// NewIdempotencyKey returns a random idempotency key.
+func NewIdempotencyKey() string {
+	return uuid.NewString()
+}
+
+// IsRetryableError reports whether a request failing with err can be retried.
+// Transport errors are retryable, unless caused by the request context or an invalid certificate.
+func IsRetryableError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var (
+		certErr      *tls.CertificateVerificationError
+		authorityErr x509.UnknownAuthorityError
+		hostnameErr  x509.HostnameError
+		invalidErr   x509.CertificateInvalidError
+	)
+	switch {
+	case errors.As(err, &certErr), errors.As(err, &authorityErr), errors.As(err, &hostnameErr), errors.As(err, &invalidErr):
+		return false
+	}
+	return true
+}
+
+// WithRetryPolicy makes the client retry failed requests according to policy.
+// Requests are retried with a delay growing exponentially, or the one set by the Retry-After header of the response.
+// Retries stop when the delay would exceed the deadline of the request context, returning the last response.
+// Requests with a body are only retried if the body can be read again, i.e. http.Request.GetBody is set.
+func WithRetryPolicy(policy RetryPolicy) APIClientOption {
+	return func(c *Client) error {
+		if policy.MaxAttempts < 1 {
+			return fmt.Errorf("invalid retry policy: max attempts must be at least 1, got %d", policy.MaxAttempts)
+		}
+		if policy.InitialBackoff < 0 || policy.MaxBackoff < 0 || policy.MaxRetryAfter < 0 {
+			return errors.New("invalid retry policy: backoff must not be negative")
+		}
+		if policy.Multiplier < 1 {
+			return fmt.Errorf("invalid retry policy: multiplier must be at least 1, got %v", policy.Multiplier)
+		}
+		if policy.Jitter < 0 || policy.Jitter > 1 {
+			return fmt.Errorf("invalid retry policy: jitter must be between 0 and 1, got %v", policy.Jitter)
+		}
+		if policy.RetryError == nil {
+			policy.RetryError = IsRetryableError
+		}
+		c.retryPolicy = &policy
+		return nil
+	}
+}
+
+// do sends the request with doer, retrying it as long as the policy allows.
+func (p *RetryPolicy) do(ctx context.Context, doer HttpRequestDoer, req *http.Request) (*http.Response, error) {
+	if p.IdempotencyKey != nil && (req.Method == http.MethodPost || req.Method == http.MethodPatch) &&
+		req.Header.Get(IdempotencyKeyHeader) == "" {
+		req.Header.Set(IdempotencyKeyHeader, p.IdempotencyKey())
+	}
+	retryable := p.isRetryableRequest(req)
+
+	for attempt := 1; ; attempt++ {
+		resp, err := doer.Do(ctx, req)
+		if !retryable || attempt >= p.MaxAttempts || !p.shouldRetry(resp, err) {
+			return resp, err
+		}
+
+		delay := p.backoff(attempt)
+		if resp != nil {
+			if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+				if limit := p.retryAfterLimit(); limit > 0 && retryAfter > limit {
+					return resp, err
+				}
+				delay = retryAfter
+			}
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			return resp, err
+		}
+
+		next, rewindErr := rewindRequest(req)
+		if rewindErr != nil {
+			return resp, err
+		}
+		discardResponse(resp)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+		req = next
+	}
+}
+
+// isRetryableRequest reports whether the method allows retrying the request, and its body can be sent again.
+func (p *RetryPolicy) isRetryableRequest(req *http.Request) bool {
+	if !slices.Contains(p.Methods, req.Method) && req.Header.Get(IdempotencyKeyHeader) == "" {
+		return false
+	}
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+func (p *RetryPolicy) shouldRetry(resp *http.Response, err error) bool {
+	if err != nil {
+		return p.RetryError(err)
+	}
+	return resp != nil && slices.Contains(p.StatusCodes, resp.StatusCode)
+}
+
+// retryAfterLimit returns the longest delay set with the Retry-After header that is waited for, zero if unlimited.
+func (p *RetryPolicy) retryAfterLimit() time.Duration {
+	if p.MaxRetryAfter > 0 {
+		return p.MaxRetryAfter
+	}
+	return p.MaxBackoff
+}
+
+// backoff returns the delay before the given retry, with jitter.
+func (p *RetryPolicy) backoff(retry int) time.Duration {
+	delay := float64(p.InitialBackoff) * math.Pow(p.Multiplier, float64(retry-1))
+	if p.MaxBackoff > 0 && delay > float64(p.MaxBackoff) {
+		delay = float64(p.MaxBackoff)
+	}
+	delay -= delay * p.Jitter * rand.Float64()
+	return time.Duration(delay)
+}
+
+// parseRetryAfter parses the Retry-After header value, either a number of seconds or an HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(max(seconds, 0)) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0), true
+	}
+	return 0, false
+}
+
+// rewindRequest returns a copy of req to send again, with a fresh body.
+func rewindRequest(req *http.Request) (*http.Request, error) {
+	next := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		next.Body = body
+	}
+	return next, nil
+}
+
+// discardResponse drains and closes the body of a response not returned, so the connection can be reused.
+func discardResponse(resp *http.Response) {
+	if resp == nil || resp.Body == nil {
+		return
+	}
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	_ = resp.Body.Close()
+}
@@ -0,0 +1,293 @@
+// Copyright 2025 DoorDash, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type retryTestResult struct {
+	status int
+	header http.Header
+	err    error
+}
+
+// scriptedDoer returns the scripted results in order, recording the requests it receives.
+type scriptedDoer struct {
+	results  []retryTestResult
+	requests []*http.Request
+	bodies   []string
+}
+
+func (d *scriptedDoer) Do(_ context.Context, req *http.Request) (*http.Response, error) {
+	d.requests = append(d.requests, req)
+	if req.Body != nil {
+		body, _ := io.ReadAll(req.Body)
+		d.bodies = append(d.bodies, string(body))
+	}
+
+	res := d.results[min(len(d.requests), len(d.results))-1]
+	if res.err != nil {
+		return nil, res.err
+	}
+	header := res.header
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		StatusCode: res.status,
+		Header:     header,
+		Body:       io.NopCloser(strings.NewReader("status")),
+	}, nil
+}
+
+func newRetryTestClient(t *testing.T, doer HttpRequestDoer, policy RetryPolicy) *Client {
+	t.Helper()
+	client, err := NewAPIClient("https://example.com", WithHTTPClient(doer), WithRetryPolicy(policy))
+	require.NoError(t, err)
+	return client
+}
+
+func testRetryPolicy() RetryPolicy {
+	policy := DefaultRetryPolicy()
+	policy.InitialBackoff = time.Millisecond
+	return policy
+}
+
+func TestWithRetryPolicy(t *testing.T) {
+	t.Run("retries retryable statuses", func(t *testing.T) {
+		doer := &scriptedDoer{results: []retryTestResult{{status: 503}, {status: 429}, {status: 200}}}
+		client := newRetryTestClient(t, doer, testRetryPolicy())
+
+		req, err := client.CreateRequest(context.Background(), RequestOptionsParameters{
+			RequestURL: "https://example.com/items/1",
+			Method:     http.MethodPut,
+			Options:    mockRequestOptions{body: map[string]string{"name": "item"}},
+		})
+		require.NoError(t, err)
+
+		resp, err := client.ExecuteRequest(context.Background(), req, "/items/{id}")
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, []string{`{"name":"item"}`, `{"name":"item"}`, `{"name":"item"}`}, doer.bodies)
+	})
+
+	t.Run("returns the last response once attempts are exhausted", func(t *testing.T) {
+		doer := &scriptedDoer{results: []retryTestResult{{status: 502}}}
+		client := newRetryTestClient(t, doer, testRetryPolicy())
+
+		req, _ := http.NewRequest(http.MethodGet, "https://example.com/items", nil)
+		resp, err := client.ExecuteRequest(context.Background(), req, "/items")
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadGateway, resp.StatusCode)
+		assert.Equal(t, []byte("status"), resp.Content)
+		assert.Len(t, doer.requests, 3)
+	})
+
+	t.Run("does not retry other statuses", func(t *testing.T) {
+		doer := &scriptedDoer{results: []retryTestResult{{status: 500}, {status: 200}}}
+		client := newRetryTestClient(t, doer, testRetryPolicy())
+
+		req, _ := http.NewRequest(http.MethodGet, "https://example.com/items", nil)
+		resp, err := client.ExecuteRequest(context.Background(), req, "/items")
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		assert.Len(t, doer.requests, 1)
+	})
+
+	t.Run("retries transport errors", func(t *testing.T) {
+		doer := &scriptedDoer{results: []retryTestResult{{err: errors.New("connection reset")}, {status: 200}}}
+		client := newRetryTestClient(t, doer, testRetryPolicy())
+
+		req, _ := http.NewRequest(http.MethodGet, "https://example.com/items", nil)
+		resp, err := client.ExecuteRequestStream(context.Background(), req, "/items")
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Len(t, doer.requests, 2)
+	})
+
+	t.Run("custom error class", func(t *testing.T) {
+		errPermanent := errors.New("permanent")
+		policy := testRetryPolicy()
+		policy.RetryError = func(err error) bool { return !errors.Is(err, errPermanent) }
+		doer := &scriptedDoer{results: []retryTestResult{{err: errPermanent}, {status: 200}}}
+		client := newRetryTestClient(t, doer, policy)
+
+		req, _ := http.NewRequest(http.MethodGet, "https://example.com/items", nil)
+		_, err := client.ExecuteRequest(context.Background(), req, "/items")
+		require.ErrorIs(t, err, errPermanent)
+		assert.Len(t, doer.requests, 1)
+	})
+
+	t.Run("does not retry non-idempotent methods", func(t *testing.T) {
+		doer := &scriptedDoer{results: []retryTestResult{{status: 503}, {status: 200}}}
+		client := newRetryTestClient(t, doer, testRetryPolicy())
+
+		req, _ := http.NewRequest(http.MethodPost, "https://example.com/items", strings.NewReader("{}"))
+		resp, err := client.ExecuteRequest(context.Background(), req, "/items")
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+		assert.Len(t, doer.requests, 1)
+	})
+
+	t.Run("retries requests with an idempotency key", func(t *testing.T) {
+		policy := testRetryPolicy()
+		policy.IdempotencyKey = func() string { return "key-1" }
+		doer := &scriptedDoer{results: []retryTestResult{{status: 503}, {status: 201}}}
+		client := newRetryTestClient(t, doer, policy)
+
+		req, _ := http.NewRequest(http.MethodPost, "https://example.com/items", strings.NewReader("{}"))
+		resp, err := client.ExecuteRequest(context.Background(), req, "/items")
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+		require.Len(t, doer.requests, 2)
+		for _, r := range doer.requests {
+			assert.Equal(t, "key-1", r.Header.Get(IdempotencyKeyHeader))
+		}
+		assert.Equal(t, []string{"{}", "{}"}, doer.bodies)
+	})
+
+	t.Run("does not retry bodies that can't be read again", func(t *testing.T) {
+		doer := &scriptedDoer{results: []retryTestResult{{status: 503}, {status: 200}}}
+		client := newRetryTestClient(t, doer, testRetryPolicy())
+
+		req, _ := http.NewRequest(http.MethodPut, "https://example.com/items", io.NopCloser(strings.NewReader("{}")))
+		req.GetBody = nil
+		resp, err := client.ExecuteRequest(context.Background(), req, "/items")
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+		assert.Len(t, doer.requests, 1)
+	})
+
+	t.Run("stops when retry-after exceeds the deadline", func(t *testing.T) {
+		doer := &scriptedDoer{results: []retryTestResult{
+			{status: 429, header: http.Header{"Retry-After": []string{"120"}}},
+			{status: 200},
+		}}
+		policy := testRetryPolicy()
+		policy.MaxRetryAfter = time.Hour
+		client := newRetryTestClient(t, doer, policy)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "https://example.com/items", nil)
+		resp, err := client.ExecuteRequest(ctx, req, "/items")
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+		assert.Len(t, doer.requests, 1)
+	})
+
+	t.Run("stops when retry-after exceeds the limit", func(t *testing.T) {
+		for name, mutate := range map[string]func(p *RetryPolicy){
+			"max retry-after": func(p *RetryPolicy) { p.MaxRetryAfter = time.Second },
+			"max backoff":     func(p *RetryPolicy) { p.MaxRetryAfter, p.MaxBackoff = 0, time.Second },
+		} {
+			doer := &scriptedDoer{results: []retryTestResult{
+				{status: 503, header: http.Header{"Retry-After": []string{"2"}}},
+				{status: 200},
+			}}
+			policy := testRetryPolicy()
+			mutate(&policy)
+			client := newRetryTestClient(t, doer, policy)
+
+			req, _ := http.NewRequest(http.MethodGet, "https://example.com/items", nil)
+			resp, err := client.ExecuteRequest(context.Background(), req, "/items")
+			require.NoError(t, err, name)
+			assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode, name)
+			assert.Len(t, doer.requests, 1, name)
+		}
+	})
+
+	t.Run("stops when the context is done", func(t *testing.T) {
+		policy := testRetryPolicy()
+		policy.InitialBackoff = time.Minute
+		doer := &scriptedDoer{results: []retryTestResult{{status: 503}, {status: 200}}}
+		client := newRetryTestClient(t, doer, policy)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(10*time.Millisecond, cancel)
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "https://example.com/items", nil)
+		_, err := client.ExecuteRequest(ctx, req, "/items")
+		require.ErrorIs(t, err, context.Canceled)
+		assert.Len(t, doer.requests, 1)
+	})
+
+	t.Run("invalid policy", func(t *testing.T) {
+		policies := map[string]func(p *RetryPolicy){
+			"max attempts": func(p *RetryPolicy) { p.MaxAttempts = 0 },
+			"backoff":      func(p *RetryPolicy) { p.InitialBackoff = -time.Second },
+			"retry-after":  func(p *RetryPolicy) { p.MaxRetryAfter = -time.Second },
+			"multiplier":   func(p *RetryPolicy) { p.Multiplier = 0.5 },
+			"jitter":       func(p *RetryPolicy) { p.Jitter = 2 },
+		}
+		for name, mutate := range policies {
+			policy := DefaultRetryPolicy()
+			mutate(&policy)
+			_, err := NewAPIClient("https://example.com", WithRetryPolicy(policy))
+			assert.ErrorContains(t, err, "invalid retry policy", name)
+		}
+	})
+}
+
+func TestRetryPolicy_backoff(t *testing.T) {
+	policy := RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second, Multiplier: 2}
+	assert.Equal(t, 100*time.Millisecond, policy.backoff(1))
+	assert.Equal(t, 400*time.Millisecond, policy.backoff(3))
+	assert.Equal(t, time.Second, policy.backoff(10))
+
+	policy.Jitter = 0.5
+	for range 100 {
+		delay := policy.backoff(2)
+		assert.GreaterOrEqual(t, delay, 100*time.Millisecond)
+		assert.LessOrEqual(t, delay, 200*time.Millisecond)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value    string
+		expected time.Duration
+		ok       bool
+	}{
+		{value: "", ok: false},
+		{value: "3", expected: 3 * time.Second, ok: true},
+		{value: "-1", expected: 0, ok: true},
+		{value: "Wed, 01 Jan 2025 12:00:30 GMT", expected: 30 * time.Second, ok: true},
+		{value: "Wed, 01 Jan 2025 11:00:00 GMT", expected: 0, ok: true},
+		{value: "soon", ok: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			delay, ok := parseRetryAfter(tt.value, now)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, delay)
+		})
+	}
+}
+
+func TestIsRetryableError(t *testing.T) {
+	assert.True(t, IsRetryableError(errors.New("connection refused")))
+	assert.False(t, IsRetryableError(context.Canceled))
+	assert.False(t, IsRetryableError(context.DeadlineExceeded))
+	assert.False(t, IsRetryableError(x509.UnknownAuthorityError{}))
+}
@@ -0,0 +1,114 @@
+// Copyright 2025 DoorDash, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// APIKeyLocation is the location of an API key as defined by the OpenAPI apiKey security scheme.
+type APIKeyLocation string
+
+const (
+	APIKeyInHeader APIKeyLocation = "header"
+	APIKeyInQuery  APIKeyLocation = "query"
+	APIKeyInCookie APIKeyLocation = "cookie"
+)
+
+// TokenSource supplies access tokens for OAuth2 and OpenID Connect security schemes.
+// Token is called for every request, so implementations are free to cache and refresh tokens.
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// StaticTokenSource is a TokenSource that always returns the same token.
+type StaticTokenSource string
+
+// Token returns the static token.
+func (s StaticTokenSource) Token(context.Context) (string, error) {
+	return string(s), nil
+}
+
+// BearerTokenEditor returns a RequestEditorFn which sets the Authorization header to "Bearer <token>".
+func BearerTokenEditor(token string) RequestEditorFn {
+	return func(_ context.Context, req *http.Request) error {
+		req.Header.Set("Authorization", "Bearer "+token)
+		return nil
+	}
+}
+
+// BasicAuthEditor returns a RequestEditorFn which sets HTTP basic authentication credentials.
+func BasicAuthEditor(username, password string) RequestEditorFn {
+	return func(_ context.Context, req *http.Request) error {
+		req.SetBasicAuth(username, password)
+		return nil
+	}
+}
+
+// APIKeyEditor returns a RequestEditorFn which sends the API key as the named header,
+// query parameter or cookie.
+func APIKeyEditor(in APIKeyLocation, name, value string) RequestEditorFn {
+	return func(_ context.Context, req *http.Request) error {
+		switch in {
+		case APIKeyInHeader:
+			req.Header.Set(name, value)
+		case APIKeyInQuery:
+			query := req.URL.Query()
+			query.Set(name, value)
+			req.URL.RawQuery = query.Encode()
+		case APIKeyInCookie:
+			req.AddCookie(&http.Cookie{Name: name, Value: value})
+		default:
+			return fmt.Errorf("unsupported API key location: %q", in)
+		}
+		return nil
+	}
+}
+
+// TokenSourceEditor returns a RequestEditorFn which fetches a token from src
+// and sends it as a bearer token.
+func TokenSourceEditor(src TokenSource) RequestEditorFn {
+	return func(ctx context.Context, req *http.Request) error {
+		if src == nil {
+			return errors.New("token source is nil")
+		}
+		token, err := src.Token(ctx)
+		if err != nil {
+			return fmt.Errorf("error getting token: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+		return nil
+	}
+}
+
+// WithBearerToken sends the given token in the Authorization header of every request.
+func WithBearerToken(token string) APIClientOption {
+	return WithRequestEditorFn(BearerTokenEditor(token))
+}
+
+// WithBasicAuth sends HTTP basic authentication credentials with every request.
+func WithBasicAuth(username, password string) APIClientOption {
+	return WithRequestEditorFn(BasicAuthEditor(username, password))
+}
+
+// WithAPIKey sends the API key with every request in the given location.
+func WithAPIKey(in APIKeyLocation, name, value string) APIClientOption {
+	return WithRequestEditorFn(APIKeyEditor(in, name, value))
+}
+
+// WithTokenSource sends a bearer token obtained from src with every request.
+// Use it for OAuth2 and OpenID Connect security schemes.
+func WithTokenSource(src TokenSource) APIClientOption {
+	return WithRequestEditorFn(TokenSourceEditor(src))
+}
@@ -0,0 +1,99 @@
+// Copyright 2025 DoorDash, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type failingTokenSource struct{}
+
+func (failingTokenSource) Token(context.Context) (string, error) {
+	return "", errors.New("expired")
+}
+
+func TestSecurityEditors(t *testing.T) {
+	newRequest := func(t *testing.T) *http.Request {
+		req, err := http.NewRequest(http.MethodGet, "https://example.com/pets?limit=10", nil)
+		require.NoError(t, err)
+		return req
+	}
+
+	t.Run("bearer token", func(t *testing.T) {
+		req := newRequest(t)
+		require.NoError(t, BearerTokenEditor("abc")(context.Background(), req))
+		assert.Equal(t, "Bearer abc", req.Header.Get("Authorization"))
+	})
+
+	t.Run("basic auth", func(t *testing.T) {
+		req := newRequest(t)
+		require.NoError(t, BasicAuthEditor("user", "pass")(context.Background(), req))
+		user, pass, ok := req.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "user", user)
+		assert.Equal(t, "pass", pass)
+	})
+
+	t.Run("api key in header", func(t *testing.T) {
+		req := newRequest(t)
+		require.NoError(t, APIKeyEditor(APIKeyInHeader, "X-API-Key", "secret")(context.Background(), req))
+		assert.Equal(t, "secret", req.Header.Get("X-API-Key"))
+	})
+
+	t.Run("api key in query", func(t *testing.T) {
+		req := newRequest(t)
+		require.NoError(t, APIKeyEditor(APIKeyInQuery, "api_key", "secret")(context.Background(), req))
+		assert.Equal(t, "secret", req.URL.Query().Get("api_key"))
+		assert.Equal(t, "10", req.URL.Query().Get("limit"))
+	})
+
+	t.Run("api key in cookie", func(t *testing.T) {
+		req := newRequest(t)
+		require.NoError(t, APIKeyEditor(APIKeyInCookie, "session", "secret")(context.Background(), req))
+		cookie, err := req.Cookie("session")
+		require.NoError(t, err)
+		assert.Equal(t, "secret", cookie.Value)
+	})
+
+	t.Run("api key in unknown location", func(t *testing.T) {
+		req := newRequest(t)
+		assert.Error(t, APIKeyEditor("body", "key", "secret")(context.Background(), req))
+	})
+
+	t.Run("token source", func(t *testing.T) {
+		req := newRequest(t)
+		require.NoError(t, TokenSourceEditor(StaticTokenSource("tok"))(context.Background(), req))
+		assert.Equal(t, "Bearer tok", req.Header.Get("Authorization"))
+	})
+
+	t.Run("token source error", func(t *testing.T) {
+		req := newRequest(t)
+		err := TokenSourceEditor(failingTokenSource{})(context.Background(), req)
+		assert.ErrorContains(t, err, "expired")
+	})
+}
+
+func TestSecurityClientOptions(t *testing.T) {
+	client, err := NewAPIClient("https://example.com",
+		WithBearerToken("abc"),
+		WithBasicAuth("user", "pass"),
+		WithAPIKey(APIKeyInHeader, "X-API-Key", "secret"),
+		WithTokenSource(StaticTokenSource("tok")),
+	)
+	require.NoError(t, err)
+	assert.Len(t, client.requestEditors, 4)
+}
@@ -0,0 +1,316 @@
+// Copyright 2025 DoorDash, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package runtime
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// SSEEvent is a Server-Sent Event.
+// Retry is the reconnection time in milliseconds, 0 if not set.
+type SSEEvent struct {
+	ID    string
+	Event string
+	Data  string
+	Retry int
+}
+
+// SSEReader reads Server-Sent Events from a text/event-stream body.
+// @see https://html.spec.whatwg.org/multipage/server-sent-events.html#event-stream-interpretation
+type SSEReader struct {
+	r      *bufio.Reader
+	lastID string
+}
+
+// NewSSEReader creates a new SSEReader reading events from r.
+func NewSSEReader(r io.Reader) *SSEReader {
+	return &SSEReader{r: bufio.NewReader(r)}
+}
+
+// Next returns the next event of the stream, or io.EOF when the stream ends.
+// Comments and events without data are skipped, an event not terminated by an empty line is discarded.
+// The ID of an event is the last ID received, which can be sent back as the Last-Event-ID header when reconnecting.
+func (s *SSEReader) Next() (*SSEEvent, error) {
+	var (
+		event   SSEEvent
+		data    strings.Builder
+		hasData bool
+	)
+
+	for {
+		line, err := s.r.ReadString('\n')
+		if err != nil {
+			// an unterminated line ends the stream, along with the pending event
+			return nil, err
+		}
+		line = strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r")
+
+		if line == "" {
+			if !hasData {
+				event = SSEEvent{}
+				continue
+			}
+			event.ID = s.lastID
+			event.Data = strings.TrimSuffix(data.String(), "\n")
+			return &event, nil
+		}
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "data":
+			data.WriteString(value)
+			data.WriteByte('\n')
+			hasData = true
+		case "event":
+			event.Event = value
+		case "id":
+			if !strings.ContainsRune(value, 0) {
+				s.lastID = value
+			}
+		case "retry":
+			if retry, err := strconv.Atoi(value); err == nil && retry >= 0 {
+				event.Retry = retry
+			}
+		}
+	}
+}
+
+// EventStream reads the events of a streamed text/event-stream response, decoding their data into T.
+// Data of string types is used as is, other types are decoded from JSON.
+// The stream must be closed once done with, unless all events are consumed with Events.
+type EventStream[T any] struct {
+	resp   *StreamResponse
+	reader *SSEReader
+	event  *SSEEvent
+	data   T
+	err    error
+}
+
+// NewEventStream creates a new EventStream reading the body of resp.
+func NewEventStream[T any](resp *StreamResponse) *EventStream[T] {
+	return &EventStream[T]{resp: resp, reader: NewSSEReader(resp.Body)}
+}
+
+// Next reads the next event, returning false when the stream ends or fails.
+// Check Err for the error that stopped the stream, if any.
+func (s *EventStream[T]) Next() bool {
+	if s.err != nil {
+		return false
+	}
+
+	event, err := s.reader.Next()
+	if err != nil {
+		if err != io.EOF {
+			s.err = err
+		}
+		return false
+	}
+
+	var data T
+	if err := decodeEventData(event.Data, &data); err != nil {
+		s.err = fmt.Errorf("error decoding event data: %w", err)
+		return false
+	}
+	s.event, s.data = event, data
+	return true
+}
+
+// Data returns the decoded data of the current event.
+func (s *EventStream[T]) Data() T {
+	return s.data
+}
+
+// Event returns the current event, with its raw data.
+func (s *EventStream[T]) Event() *SSEEvent {
+	return s.event
+}
+
+// Err returns the error that stopped the stream, nil if it ended normally.
+func (s *EventStream[T]) Err() error {
+	return s.err
+}
+
+// Response returns the response the events are read from.
+func (s *EventStream[T]) Response() *StreamResponse {
+	return s.resp
+}
+
+// Close closes the response body.
+func (s *EventStream[T]) Close() error {
+	return s.resp.Close()
+}
+
+// Events returns an iterator over the decoded events, closing the stream when the iteration stops.
+// An error stopping the stream is yielded last.
+func (s *EventStream[T]) Events() iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		defer func() { _ = s.Close() }()
+		for s.Next() {
+			if !yield(s.data, nil) {
+				return
+			}
+		}
+		if s.err != nil {
+			var zero T
+			yield(zero, s.err)
+		}
+	}
+}
+
+// EventSender sends typed Server-Sent Events to the client, flushing each of them.
+// Data of string types is sent as is, other types are encoded as JSON.
+// The response status and headers are sent with the first event, set them on Header before.
+// Sending fails with ErrEventStreamNotFlushable if the response writer can't be flushed.
+type EventSender[T any] struct {
+	w       http.ResponseWriter
+	rc      *http.ResponseController
+	status  int
+	started bool
+}
+
+// NewEventSender creates a new EventSender writing events to w with the given success status.
+func NewEventSender[T any](w http.ResponseWriter, status int) *EventSender[T] {
+	return &EventSender[T]{w: w, rc: http.NewResponseController(w), status: status}
+}
+
+// Header returns the response headers, sent with the first event.
+func (s *EventSender[T]) Header() http.Header {
+	return s.w.Header()
+}
+
+// Started returns true once the response status and headers are sent.
+func (s *EventSender[T]) Started() bool {
+	return s.started
+}
+
+// Start sends the response status and headers, if not sent yet.
+func (s *EventSender[T]) Start() error {
+	if s.started {
+		return nil
+	}
+	s.started = true
+
+	header := s.w.Header()
+	header.Set("Content-Type", "text/event-stream")
+	if header.Get("Cache-Control") == "" {
+		header.Set("Cache-Control", "no-cache")
+	}
+	s.w.WriteHeader(s.status)
+	return s.flush()
+}
+
+// Send sends an event with the given data.
+func (s *EventSender[T]) Send(data T) error {
+	return s.SendEvent(SSEEvent{}, data)
+}
+
+// SendEvent sends an event with the given data, and the ID, name and retry of event.
+// The data of event is ignored.
+func (s *EventSender[T]) SendEvent(event SSEEvent, data T) error {
+	encoded, err := encodeEventData(data)
+	if err != nil {
+		return fmt.Errorf("error encoding event data: %w", err)
+	}
+	event.Data = encoded
+	return s.write(formatSSEEvent(event))
+}
+
+// SendComment sends a comment, which clients ignore, e.g. to keep the connection alive.
+func (s *EventSender[T]) SendComment(comment string) error {
+	var b strings.Builder
+	for _, line := range splitSSELines(comment) {
+		b.WriteString(": ")
+		b.WriteString(line)
+		b.WriteByte('\n')
+	}
+	b.WriteByte('\n')
+	return s.write(b.String())
+}
+
+func (s *EventSender[T]) write(payload string) error {
+	if err := s.Start(); err != nil {
+		return err
+	}
+	if _, err := io.WriteString(s.w, payload); err != nil {
+		return err
+	}
+	return s.flush()
+}
+
+func (s *EventSender[T]) flush() error {
+	err := s.rc.Flush()
+	if errors.Is(err, http.ErrNotSupported) {
+		return ErrEventStreamNotFlushable
+	}
+	return err
+}
+
+// formatSSEEvent formats an event in the text/event-stream format.
+func formatSSEEvent(event SSEEvent) string {
+	var b strings.Builder
+	if event.ID != "" {
+		b.WriteString("id: " + singleSSELine(event.ID) + "\n")
+	}
+	if event.Event != "" {
+		b.WriteString("event: " + singleSSELine(event.Event) + "\n")
+	}
+	if event.Retry > 0 {
+		b.WriteString("retry: " + strconv.Itoa(event.Retry) + "\n")
+	}
+	for _, line := range splitSSELines(event.Data) {
+		b.WriteString("data: " + line + "\n")
+	}
+	b.WriteByte('\n')
+	return b.String()
+}
+
+func splitSSELines(s string) []string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	return strings.Split(strings.ReplaceAll(s, "\r", "\n"), "\n")
+}
+
+func singleSSELine(s string) string {
+	return strings.NewReplacer("\r", " ", "\n", " ").Replace(s)
+}
+
+func decodeEventData(data string, target any) error {
+	v := reflect.ValueOf(target).Elem()
+	if v.Kind() == reflect.String {
+		v.SetString(data)
+		return nil
+	}
+	return json.Unmarshal([]byte(data), target)
+}
+
+func encodeEventData(data any) (string, error) {
+	v := reflect.ValueOf(data)
+	if v.Kind() == reflect.String {
+		return v.String(), nil
+	}
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
@@ -0,0 +1,152 @@
+// Copyright 2025 DoorDash, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific language governing permissions and limitations under the License.
+
+package runtime
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSSEReader(t *testing.T) {
+	stream := ": welcome\n" +
+		"retry: 3000\n" +
+		"id: 1\n" +
+		"event: created\n" +
+		"data: {\"a\":\n" +
+		"data:1}\r\n" +
+		"\r\n" +
+		"event: ignored without data\n" +
+		"\n" +
+		"data: second\n" +
+		"\n" +
+		"data: unterminated"
+
+	r := NewSSEReader(strings.NewReader(stream))
+
+	event, err := r.Next()
+	require.NoError(t, err)
+	assert.Equal(t, &SSEEvent{ID: "1", Event: "created", Data: "{\"a\":\n1}", Retry: 3000}, event)
+
+	event, err = r.Next()
+	require.NoError(t, err)
+	assert.Equal(t, &SSEEvent{ID: "1", Data: "second"}, event)
+
+	_, err = r.Next()
+	assert.Equal(t, io.EOF, err)
+}
+
+type sseTestEvent struct {
+	Message string `json:"message"`
+}
+
+func TestEventStream(t *testing.T) {
+	newStream := func(body string) *EventStream[sseTestEvent] {
+		return NewEventStream[sseTestEvent](&StreamResponse{
+			Body:       io.NopCloser(strings.NewReader(body)),
+			StatusCode: http.StatusOK,
+		})
+	}
+
+	t.Run("next", func(t *testing.T) {
+		s := newStream("event: a\ndata: {\"message\":\"one\"}\n\ndata: {\"message\":\"two\"}\n\n")
+
+		require.True(t, s.Next())
+		assert.Equal(t, sseTestEvent{Message: "one"}, s.Data())
+		assert.Equal(t, "a", s.Event().Event)
+		require.True(t, s.Next())
+		assert.Equal(t, sseTestEvent{Message: "two"}, s.Data())
+		assert.False(t, s.Next())
+		assert.NoError(t, s.Err())
+	})
+
+	t.Run("events", func(t *testing.T) {
+		s := newStream("data: {\"message\":\"one\"}\n\ndata: nope\n\ndata: {\"message\":\"three\"}\n\n")
+
+		var (
+			messages []string
+			errs     []error
+		)
+		for event, err := range s.Events() {
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			messages = append(messages, event.Message)
+		}
+		assert.Equal(t, []string{"one"}, messages)
+		require.Len(t, errs, 1)
+		assert.ErrorContains(t, errs[0], "error decoding event data")
+	})
+
+	t.Run("string data", func(t *testing.T) {
+		s := NewEventStream[string](&StreamResponse{Body: io.NopCloser(strings.NewReader("data: plain text\n\n"))})
+
+		require.True(t, s.Next())
+		assert.Equal(t, "plain text", s.Data())
+	})
+}
+
+func TestEventSender(t *testing.T) {
+	t.Run("events", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		sender := NewEventSender[sseTestEvent](rec, http.StatusOK)
+		sender.Header().Set("X-Stream", "1")
+		assert.False(t, sender.Started())
+
+		require.NoError(t, sender.Send(sseTestEvent{Message: "one"}))
+		require.NoError(t, sender.SendEvent(SSEEvent{ID: "2", Event: "update\nx", Retry: 100}, sseTestEvent{Message: "two"}))
+		require.NoError(t, sender.SendComment("ping"))
+		assert.True(t, sender.Started())
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.True(t, rec.Flushed)
+		assert.Equal(t, "text/event-stream", rec.Header().Get("Content-Type"))
+		assert.Equal(t, "no-cache", rec.Header().Get("Cache-Control"))
+		assert.Equal(t, "1", rec.Header().Get("X-Stream"))
+		assert.Equal(t, "data: {\"message\":\"one\"}\n\n"+
+			"id: 2\nevent: update x\nretry: 100\ndata: {\"message\":\"two\"}\n\n"+
+			": ping\n\n", rec.Body.String())
+	})
+
+	t.Run("multiline string data", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		sender := NewEventSender[string](rec, http.StatusOK)
+		require.NoError(t, sender.Send("line one\nline two"))
+		assert.Equal(t, "data: line one\ndata: line two\n\n", rec.Body.String())
+
+		// the reader restores the data
+		event, err := NewSSEReader(strings.NewReader(rec.Body.String())).Next()
+		require.NoError(t, err)
+		assert.Equal(t, "line one\nline two", event.Data)
+	})
+
+	t.Run("writer not flushable", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		sender := NewEventSender[string](struct{ http.ResponseWriter }{rec}, http.StatusOK)
+		require.ErrorIs(t, sender.Send("one"), ErrEventStreamNotFlushable)
+		assert.True(t, sender.Started())
+	})
+
+	t.Run("start without events", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		sender := NewEventSender[string](rec, http.StatusAccepted)
+		require.NoError(t, sender.Start())
+		require.NoError(t, sender.Start())
+		assert.Equal(t, http.StatusAccepted, rec.Code)
+		assert.Empty(t, rec.Body.String())
+	})
+}
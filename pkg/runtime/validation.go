@@ -17,6 +17,7 @@ package runtime
 import (
 	"errors"
 	"reflect"
+	"strings"
 
 	"github.com/go-playground/validator/v10"
 )
@@ -76,3 +77,60 @@ func ConvertValidatorError(err error) error {
 	// Use the existing NewValidationErrorsFromError which handles validator errors properly
 	return NewValidationErrorsFromError(err)
 }
+
+// UnionVariant is an element of a oneOf/anyOf union.
+type UnionVariant struct {
+	// Name identifies the element in validation errors.
+	Name string
+
+	// Validate decodes the union data as the element and validates it.
+	Validate func() error
+}
+
+// ValidateOneOf checks that the union data matches exactly one of the variants.
+// If none matches, the errors of every variant are reported, prefixed with its name.
+func ValidateOneOf(variants ...UnionVariant) error {
+	matched, errs := matchUnionVariants(variants)
+	switch len(matched) {
+	case 1:
+		return nil
+	case 0:
+		return append(NewValidationErrorsFromString("", "must match exactly one of "+unionVariantNames(variants)), errs...)
+	default:
+		return NewValidationErrorsFromString("", "must match exactly one of "+unionVariantNames(variants)+
+			", but matches "+strings.Join(matched, ", "))
+	}
+}
+
+// ValidateAnyOf checks that the union data matches at least one of the variants.
+// If none matches, the errors of every variant are reported, prefixed with its name.
+func ValidateAnyOf(variants ...UnionVariant) error {
+	matched, errs := matchUnionVariants(variants)
+	if len(matched) > 0 {
+		return nil
+	}
+	return append(NewValidationErrorsFromString("", "must match at least one of "+unionVariantNames(variants)), errs...)
+}
+
+func matchUnionVariants(variants []UnionVariant) ([]string, ValidationErrors) {
+	var (
+		matched []string
+		errs    ValidationErrors
+	)
+	for _, variant := range variants {
+		if err := variant.Validate(); err != nil {
+			errs = errs.Append(variant.Name, err)
+			continue
+		}
+		matched = append(matched, variant.Name)
+	}
+	return matched, errs
+}
+
+func unionVariantNames(variants []UnionVariant) string {
+	names := make([]string, len(variants))
+	for i, variant := range variants {
+		names[i] = variant.Name
+	}
+	return strings.Join(names, ", ")
+}
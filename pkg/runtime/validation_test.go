@@ -229,3 +229,53 @@ func TestConvertValidatorError(t *testing.T) {
 		assert.Len(t, unwrapped, 2)
 	})
 }
+
+func TestValidateUnion(t *testing.T) {
+	ok := func(name string) UnionVariant {
+		return UnionVariant{Name: name, Validate: func() error { return nil }}
+	}
+	failing := func(name string) UnionVariant {
+		return UnionVariant{Name: name, Validate: func() error {
+			return NewValidationErrorsFromString("number", "is required")
+		}}
+	}
+
+	t.Run("oneOf matches exactly one", func(t *testing.T) {
+		assert.NoError(t, ValidateOneOf(failing("Card"), ok("Wallet"), failing("Voucher")))
+	})
+
+	t.Run("oneOf matches none", func(t *testing.T) {
+		err := ValidateOneOf(failing("Card"), failing("Wallet"), failing("Voucher"))
+
+		var ves ValidationErrors
+		require.True(t, errors.As(err, &ves))
+		require.Len(t, ves, 4)
+		assert.Equal(t, "must match exactly one of Card, Wallet, Voucher", ves[0].Message)
+		assert.Equal(t, "Card.number", ves[1].Field)
+		assert.Equal(t, "Wallet.number", ves[2].Field)
+		assert.Equal(t, "Voucher.number", ves[3].Field)
+	})
+
+	t.Run("oneOf matches several", func(t *testing.T) {
+		err := ValidateOneOf(ok("Card"), ok("Wallet"), failing("Voucher"))
+
+		var ves ValidationErrors
+		require.True(t, errors.As(err, &ves))
+		require.Len(t, ves, 1)
+		assert.Equal(t, "must match exactly one of Card, Wallet, Voucher, but matches Card, Wallet", ves[0].Message)
+	})
+
+	t.Run("anyOf matches several", func(t *testing.T) {
+		assert.NoError(t, ValidateAnyOf(ok("Email"), ok("Phone"), failing("Address")))
+	})
+
+	t.Run("anyOf matches none", func(t *testing.T) {
+		err := ValidateAnyOf(failing("Email"), failing("Phone"), failing("Address"))
+
+		var ves ValidationErrors
+		require.True(t, errors.As(err, &ves))
+		require.Len(t, ves, 4)
+		assert.Equal(t, "must match at least one of Email, Phone, Address", ves[0].Message)
+		assert.Equal(t, "Address.number", ves[3].Field)
+	})
+}